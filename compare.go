@@ -0,0 +1,187 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// dateLayouts 日期字符串支持的格式，按顺序尝试解析
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// sameValidator 与其他属性相同
+// 两者均为字符串时按字符串比较，否则按数字（int*、float*、数字字符串）、日期（time.Time、日期字符串）比较，均不能比较时按值比较
+// Rule.Required    bool      可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Other       string    必选    其他属性，支持嵌套属性路径（相对于被验证属性所在的对象），其他属性无值时验证失败
+func (this *validator) sameValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseField)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 比较
+	if other, ok := lookup(obj, p.others[0]); !ok || !equal(obj[attr], other) {
+		return this.compared("same", attr, rule, p.others[:1])
+	}
+	return nil
+}
+
+// differentValidator 与其他属性不同，比较方式同 sameValidator
+// Rule.Required    bool      可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Other       string    必选    其他属性，支持嵌套属性路径（相对于被验证属性所在的对象），其他属性无值时验证通过
+func (this *validator) differentValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseField)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 比较
+	if other, ok := lookup(obj, p.others[0]); ok && equal(obj[attr], other) {
+		return this.compared("different", attr, rule, p.others[:1])
+	}
+	return nil
+}
+
+// gtFieldValidator 大于其他属性
+// 支持数字（int*、float*、数字字符串）、日期（time.Time、日期字符串，见 dateLayouts），两者不能比较时报 compare 错误
+// Rule.Required    bool      可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Other       string    必选    其他属性，支持嵌套属性路径（相对于被验证属性所在的对象），其他属性无值时跳过
+func (this *validator) gtFieldValidator(attr string, rule Rule, obj M) Errors {
+	return this.compareField("gtField", attr, rule, obj, func(c int) bool { return c > 0 })
+}
+
+// gteFieldValidator 大于或等于其他属性，同 gtFieldValidator
+func (this *validator) gteFieldValidator(attr string, rule Rule, obj M) Errors {
+	return this.compareField("gteField", attr, rule, obj, func(c int) bool { return c >= 0 })
+}
+
+// ltFieldValidator 小于其他属性，同 gtFieldValidator
+func (this *validator) ltFieldValidator(attr string, rule Rule, obj M) Errors {
+	return this.compareField("ltField", attr, rule, obj, func(c int) bool { return c < 0 })
+}
+
+// lteFieldValidator 小于或等于其他属性，同 gtFieldValidator
+func (this *validator) lteFieldValidator(attr string, rule Rule, obj M) Errors {
+	return this.compareField("lteField", attr, rule, obj, func(c int) bool { return c <= 0 })
+}
+
+// compareField 与其他属性比较，pass 为比较结果（见 compare）满足的条件
+func (this *validator) compareField(name string, attr string, rule Rule, obj M, pass func(int) bool) Errors {
+	p := paramsOf(rule, parseField)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	other, ok := lookup(obj, p.others[0])
+	if !ok { // 其他属性无值
+		return nil
+	}
+	// 比较
+	c, ok := compare(obj[attr], other)
+	if !ok {
+		return this.compared("compare", attr, rule, p.others[:1])
+	}
+	if !pass(c) {
+		return this.compared(name, attr, rule, p.others[:1])
+	}
+	return nil
+}
+
+// equal 两个值是否相同，均为字符串时按字符串比较，否则使用 compare 比较，不能比较时按值比较
+func equal(a, b interface{}) bool {
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			return sa == sb
+		}
+	}
+	if c, ok := compare(a, b); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare 比较两个值，a < b 返回 -1，a == b 返回 0，a > b 返回 1
+// 均为数字（int*、float*、数字字符串）时按数字比较，均为日期（time.Time、日期字符串）时按时间比较，其他 ok 为 false
+func compare(a, b interface{}) (c int, ok bool) {
+	if fa, ok := toNumber(a); ok {
+		if fb, ok := toNumber(b); ok {
+			switch {
+			case fa < fb:
+				return -1, true
+			case fa > fb:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if ta, ok := toTime(a); ok {
+		if tb, ok := toTime(b); ok {
+			switch {
+			case ta.Before(tb):
+				return -1, true
+			case ta.After(tb):
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// toNumber 转换为 float64，支持类型 int64、int32、int16、int8、int、float64、float32、数字字符串
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// toTime 转换为 time.Time，支持类型 time.Time、*time.Time、日期字符串（见 dateLayouts）
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
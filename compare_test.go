@@ -0,0 +1,236 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	// "github.com/goindow/toolbox"
+)
+
+/***** sameValidator *****/
+
+// 有值，与其他属性不同
+func Test_Rule_SameValidator(t *testing.T) {
+	rules := Rules{
+		"signup": {
+			{Attr: "rpassword", Rule: "same", Other: "password"},
+		},
+	}
+	message := generator(v.default_errors["same"], "rpassword", "password")
+	for _, obj := range []M{
+		{"password": "Abc123", "rpassword": "abc123"},
+		{"password": "123456", "rpassword": "123456.0"}, // 均为字符串时按字符串比较
+		{"rpassword": "Abc123"},                         // 其他属性无值
+	} {
+		e := v.Validate(rules, obj, "signup")
+		// toolbox.Dump(e) // [map[rpassword:必须与 password 相同]]
+		if len(e) == 0 || e[0]["rpassword"] != message {
+			fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+		}
+	}
+}
+
+// 有值，与其他属性相同
+func Test_Rule_SameValidator_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "a", Rule: "same", Other: "b"},
+		},
+	}
+	for _, obj := range []M{
+		{"a": "Abc123", "b": "Abc123"},
+		{"a": 10, "b": float64(10)},
+		{"a": int32(10), "b": "10"},
+		{"a": "2019-01-02", "b": time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"a": true, "b": true},
+	} {
+		if e := v.Validate(rules, obj, "create"); len(e) != 0 {
+			fail(t, "should print nothing, got "+fmt.Sprint(e))
+		}
+	}
+}
+
+// 嵌套属性路径
+func Test_Rule_SameValidator_Path(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items.*.confirm", Rule: "same", Other: "sku"},
+		},
+	}
+	obj := M{"items": []interface{}{M{"sku": "a", "confirm": "a"}, M{"sku": "b", "confirm": "c"}}}
+	message := generator(v.default_errors["same"], "items.1.confirm", "sku")
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[items.1.confirm:必须与 sku 相同]]
+	if len(e) != 1 || e[0]["items.1.confirm"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 无值 Rule.Required == true
+func Test_Rule_SameValidator_Required_True_Empty(t *testing.T) {
+	rules := Rules{
+		"signup": {
+			{Attr: "rpassword", Rule: "same", Other: "password", Required: true},
+		},
+	}
+	message := generator(v.default_errors["required"], "rpassword")
+	e := v.Validate(rules, M{"password": "Abc123"}, "signup")
+	// toolbox.Dump(e) // [map[rpassword:不能为空]]
+	if len(e) == 0 || e[0]["rpassword"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 未传参 Rule.Other 或类型错误
+func Test_Rule_SameValidator_NotFound_Other(t *testing.T) {
+	for _, rule := range []Rule{
+		{Attr: "rpassword", Rule: "same"},
+		{Attr: "rpassword", Rule: "same", Other: []string{"password"}},
+		{Attr: "start", Rule: "lt_field", Other: ""},
+	} {
+		if _, err := v.ValidateE(Rules{"create": {rule}}, objEmpty, "create"); !errors.Is(err, ErrInvalidRuleParam) {
+			fail(t, "should return error(invalid rule param), got "+fmt.Sprint(err))
+		}
+	}
+}
+
+/***** differentValidator *****/
+
+// 有值，与其他属性相同
+func Test_Rule_DifferentValidator(t *testing.T) {
+	rules := Rules{
+		"update": {
+			{Attr: "new_password", Rule: "different", Other: "password"},
+		},
+	}
+	message := generator(v.default_errors["different"], "new_password", "password")
+	e := v.Validate(rules, M{"password": "Abc123", "new_password": "Abc123"}, "update")
+	// toolbox.Dump(e) // [map[new_password:不能与 password 相同]]
+	if len(e) == 0 || e[0]["new_password"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 有值，与其他属性不同或其他属性无值
+func Test_Rule_DifferentValidator_OK(t *testing.T) {
+	rules := Rules{
+		"update": {
+			{Attr: "new_password", Rule: "different", Other: "password"},
+		},
+	}
+	for _, obj := range []M{
+		{"password": "Abc123", "new_password": "Abc1234"},
+		{"new_password": "Abc123"},
+	} {
+		if e := v.Validate(rules, obj, "update"); len(e) != 0 {
+			fail(t, "should print nothing, got "+fmt.Sprint(e))
+		}
+	}
+}
+
+/***** gtFieldValidator、gteFieldValidator、ltFieldValidator、lteFieldValidator *****/
+
+// 数字比较，支持 int*、float*、数字字符串混合比较
+func Test_Rule_CompareFieldValidator_Number(t *testing.T) {
+	cases := []struct {
+		rule string
+		a, b interface{}
+		pass bool
+	}{
+		{"gt_field", 11, float64(10), true},
+		{"gt_field", "10", int8(10), false},
+		{"gte_field", float32(10), "10", true},
+		{"gte_field", int64(9), 10.5, false},
+		{"lt_field", "9.5", 10, true},
+		{"lt_field", int16(10), int32(10), false},
+		{"lte_field", 10, "10.0", true},
+		{"lte_field", 10.01, 10, false},
+	}
+	for _, c := range cases {
+		rules := Rules{"create": {{Attr: "max", Rule: c.rule, Other: "min"}}}
+		e := v.Validate(rules, M{"max": c.a, "min": c.b}, "create")
+		if c.pass && len(e) != 0 {
+			fail(t, c.rule+" should print nothing, got "+fmt.Sprint(e))
+		}
+		if !c.pass && len(e) == 0 {
+			fail(t, c.rule+" should print error, got nothing")
+		}
+	}
+}
+
+// 日期比较，支持 time.Time、日期字符串
+func Test_Rule_CompareFieldValidator_Date(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "end_date", Rule: "gt_field", Other: "start_date"},
+			{Attr: "start_date", Rule: "lte_field", Other: "end_date"},
+		},
+	}
+	for _, obj := range []M{
+		{"start_date": "2019-01-02", "end_date": "2019-01-03"},
+		{"start_date": "2019-01-02 08:00:00", "end_date": "2019-01-02T08:00:01Z"},
+		{"start_date": time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC), "end_date": "2019-01-03"},
+	} {
+		if e := v.Validate(rules, obj, "create"); len(e) != 0 {
+			fail(t, "should print nothing, got "+fmt.Sprint(e))
+		}
+	}
+	message := generator(v.default_errors["gtField"], "end_date", "start_date")
+	e := v.Validate(rules, M{"start_date": "2019-01-03", "end_date": "2019-01-02"}, "create")
+	// toolbox.Dump(e) // [map[end_date:必须大于 start_date] map[start_date:必须小于或等于 end_date]]
+	if len(e) != 2 || e[0]["end_date"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 不能比较
+func Test_Rule_CompareFieldValidator_Incomparable(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "end_date", Rule: "gt_field", Other: "start_date"},
+		},
+	}
+	message := generator(v.default_errors["compare"], "end_date", "start_date")
+	for _, obj := range []M{
+		{"start_date": "2019-01-02", "end_date": 10},
+		{"start_date": "tomorrow", "end_date": "today"},
+		{"start_date": true, "end_date": false},
+	} {
+		e := v.Validate(rules, obj, "create")
+		// toolbox.Dump(e) // [map[end_date:无法与 start_date 比较]]
+		if len(e) == 0 || e[0]["end_date"] != message {
+			fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+		}
+	}
+}
+
+// 无值，其他属性无值
+func Test_Rule_CompareFieldValidator_Empty(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "max", Rule: "gt_field", Other: "min"},
+			{Attr: "min", Rule: "lt_field", Other: "max"},
+		},
+	}
+	for _, obj := range []M{objEmpty, {"max": 10}, {"min": 10}} {
+		if e := v.Validate(rules, obj, "create"); len(e) != 0 {
+			fail(t, "should print nothing, got "+fmt.Sprint(e))
+		}
+	}
+}
+
+// 英文错误信息包含其他属性
+func Test_Rule_CompareFieldValidator_Lang(t *testing.T) {
+	en := New().Lang(EN_US)
+	rules := Rules{
+		"create": {
+			{Attr: "max", Rule: "gte_field", Other: "min"},
+		},
+	}
+	e := en.Validate(rules, M{"max": 1, "min": 2}, "create")
+	// toolbox.Dump(e) // [map[max:must be greater than or equal to min]]
+	if len(e) == 0 || e[0]["max"] != "must be greater than or equal to min" {
+		fail(t, "should print error(must be greater than or equal to min), got "+fmt.Sprint(e))
+	}
+}
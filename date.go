@@ -0,0 +1,235 @@
+package validator
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 日期/时间验证器的默认格式（RFC 3339 的 full-date、date-time、partial-time）
+const (
+	LAYOUT_DATE     = "2006-01-02"
+	LAYOUT_DATETIME = time.RFC3339
+	LAYOUT_TIME     = "15:04:05"
+)
+
+// defaultLayouts 各验证器的默认格式
+var defaultLayouts = map[string]string{
+	"date":     LAYOUT_DATE,
+	"datetime": LAYOUT_DATETIME,
+	"time":     LAYOUT_TIME,
+}
+
+// now 当前时间，便于测试
+var now = time.Now
+
+// relative 相对时间，如 now、today、now+7d、today-1mo，单位 s、m、h、d、w、mo、y
+var relative = regexp.MustCompile(`^(now|today|tomorrow|yesterday)(?:\s*([+-])\s*(\d+)\s*(s|m|h|d|w|mo|y))?$`)
+
+// bound 日期/时间的边界（Rule.Before、Rule.After），绝对时间或相对于验证时的时间
+type bound struct {
+	// 绝对时间
+	at time.Time
+	// 相对时间的基准，now、today、tomorrow、yesterday，绝对时间为空字符串
+	base string
+	// 相对时间的偏移，如 +7d 为 7、"d"
+	offset int
+	unit   string
+}
+
+// resolve 边界的具体时间，相对时间以 loc 时区的当前时间计算
+func (this *bound) resolve(loc *time.Location) time.Time {
+	if this.base == "" {
+		return this.at
+	}
+	t := now().In(loc)
+	if this.base != "now" {
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		switch this.base {
+		case "tomorrow":
+			t = t.AddDate(0, 0, 1)
+		case "yesterday":
+			t = t.AddDate(0, 0, -1)
+		}
+	}
+	switch this.unit {
+	case "s":
+		return t.Add(time.Duration(this.offset) * time.Second)
+	case "m":
+		return t.Add(time.Duration(this.offset) * time.Minute)
+	case "h":
+		return t.Add(time.Duration(this.offset) * time.Hour)
+	case "d":
+		return t.AddDate(0, 0, this.offset)
+	case "w":
+		return t.AddDate(0, 0, 7*this.offset)
+	case "mo":
+		return t.AddDate(0, this.offset, 0)
+	case "y":
+		return t.AddDate(this.offset, 0, 0)
+	}
+	return t
+}
+
+// dateValidator 日期，如 2006-01-02
+// 支持类型 string（按 Rule.Layout 解析）、time.Time、*time.Time、Unix 时间戳（int*、float*，秒）
+// Rule.Required    bool               可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Layout      string|[]string    可选    格式，多个格式依次尝试，默认为 LAYOUT_DATE
+// Rule.Timezone    string             可选    时区（IANA 时区名，如 Asia/Shanghai、UTC、Local），用于解析不含时区的字符串及计算相对时间，默认为 UTC
+// Rule.Before      time.Time|string   可选    必须早于，字符串为 Rule.Layout 格式的绝对时间或相对时间（now、today、tomorrow、yesterday，可带偏移，如 now+7d、today-1mo）
+// Rule.After       time.Time|string   可选    必须晚于，同 Rule.Before
+func (this *validator) dateValidator(attr string, rule Rule, obj M) Errors {
+	return this.dateTime("date", attr, rule, obj)
+}
+
+// datetimeValidator 日期时间，默认格式为 LAYOUT_DATETIME（RFC 3339），同 dateValidator
+func (this *validator) datetimeValidator(attr string, rule Rule, obj M) Errors {
+	return this.dateTime("datetime", attr, rule, obj)
+}
+
+// timeValidator 时间，默认格式为 LAYOUT_TIME，仅比较一天中的时间（time.Time、Unix 时间戳取 Rule.Timezone 时区的时间），同 dateValidator
+func (this *validator) timeValidator(attr string, rule Rule, obj M) Errors {
+	return this.dateTime("time", attr, rule, obj)
+}
+
+// dateTime 日期/时间验证，name 为 date、datetime、time
+func (this *validator) dateTime(name string, attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseDate)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 格式检测
+	t, ok := toDate(obj[attr], p.layouts, p.loc)
+	if !ok {
+		return this.failure(name, attr, rule, "layout", strings.Join(p.layouts, "、"))
+	}
+	// 边界检测
+	clock := name == "time"
+	if clock {
+		t = clockOf(t)
+	}
+	if p.before != nil {
+		before := p.before.resolve(p.loc)
+		if clock {
+			before = clockOf(before)
+		}
+		if !t.Before(before) {
+			return this.failure("before", attr, rule, "before", before.Format(p.layouts[0]))
+		}
+	}
+	if p.after != nil {
+		after := p.after.resolve(p.loc)
+		if clock {
+			after = clockOf(after)
+		}
+		if !t.After(after) {
+			return this.failure("after", attr, rule, "after", after.Format(p.layouts[0]))
+		}
+	}
+	return nil
+}
+
+// toDate 转换为 loc 时区的 time.Time，支持类型 string（按 layouts 依次解析）、time.Time、*time.Time、Unix 时间戳（int*、float*，秒）
+func toDate(value interface{}, layouts []string, loc *time.Location) (time.Time, bool) {
+	switch v := value.(type) {
+	case string:
+		for _, layout := range layouts {
+			if t, err := time.ParseInLocation(layout, v, loc); err == nil {
+				return t.In(loc), true
+			}
+		}
+		return time.Time{}, false
+	case time.Time:
+		return v.In(loc), true
+	case *time.Time:
+		if v != nil {
+			return v.In(loc), true
+		}
+		return time.Time{}, false
+	case int, int8, int16, int32, int64:
+		sec, _ := toNumber(v)
+		return time.Unix(int64(sec), 0).In(loc), true
+	case float64, float32:
+		f, _ := toNumber(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return time.Time{}, false
+		}
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).In(loc), true
+	}
+	return time.Time{}, false
+}
+
+// clockOf 一天中的时间，日期统一为 0000-01-01
+func clockOf(t time.Time) time.Time {
+	return time.Date(0, 1, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// parseDate dateValidator、datetimeValidator、timeValidator 规则参数
+// Rule.Layout 只能是 string 或 []string，Rule.Timezone 必须是有效的时区，Rule.Before/Rule.After 只能是 time.Time 或有效的时间字符串
+func parseDate(rule Rule) *params {
+	p := &params{loc: time.UTC}
+	switch layout := rule.Layout.(type) {
+	case nil:
+		p.layouts = []string{defaultLayouts[rule.Rule]}
+	case string:
+		p.layouts = []string{layout}
+	case []string:
+		p.layouts = layout
+	default:
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Layout' should be 'string' or '[]string'"))
+	}
+	if len(p.layouts) == 0 {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Layout' should not be empty"))
+	}
+	for _, layout := range p.layouts {
+		if layout == "" {
+			panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Layout' should not be empty"))
+		}
+	}
+	if rule.Timezone != "" {
+		loc, err := time.LoadLocation(rule.Timezone)
+		if err != nil {
+			panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Timezone' "+err.Error()))
+		}
+		p.loc = loc
+	}
+	p.before = parseDateBound(rule, "Before", rule.Before, p)
+	p.after = parseDateBound(rule, "After", rule.After, p)
+	if p.before != nil && p.after != nil && p.before.base == "" && p.after.base == "" && !p.after.at.Before(p.before.at) {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Before' should be later than 'After'"))
+	}
+	return p
+}
+
+// parseDateBound 解析 Rule.Before 或 Rule.After
+func parseDateBound(rule Rule, name string, value interface{}, p *params) *bound {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		return &bound{at: v}
+	case string:
+		if m := relative.FindStringSubmatch(strings.ToLower(strings.TrimSpace(v))); m != nil {
+			b := &bound{base: m[1], unit: m[4]}
+			if m[3] != "" {
+				b.offset, _ = strconv.Atoi(m[3])
+				if m[2] == "-" {
+					b.offset = -b.offset
+				}
+			}
+			return b
+		}
+		if t, ok := toDate(v, p.layouts, p.loc); ok {
+			return &bound{at: t}
+		}
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute '"+name+"' should be a time in 'Layout' or a relative time like now+7d"))
+	}
+	panic(newRuleError(ErrInvalidRuleParam, rule, "attribute '"+name+"' should be 'time.Time' or 'string'"))
+}
@@ -0,0 +1,212 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+	// "github.com/goindow/toolbox"
+)
+
+// clock 固定当前时间为 2024-05-20 10:30:00 UTC，返回恢复函数
+func clock() func() {
+	now = func() time.Time { return time.Date(2024, 5, 20, 10, 30, 0, 0, time.UTC) }
+	return func() { now = time.Now }
+}
+
+/***** dateValidator *****/
+
+// 默认格式
+func Test_Rule_DateValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "birthday", Rule: "date"}}}
+	for _, value := range []interface{}{"2024-02-29", time.Now(), &time.Time{}, 1716201000, int64(1716201000), 1716201000.5} {
+		if e := v.Validate(rules, M{"birthday": value}, "create"); len(e) != 0 {
+			fail(t, fmt.Sprintf("%#v should be valid, got %v", value, e))
+		}
+	}
+	for _, value := range []interface{}{"2023-02-29", "2024-5-20", "2024-05-20T10:30:00Z", true, (*time.Time)(nil), []string{}} {
+		e := v.Validate(rules, M{"birthday": value}, "create")
+		// toolbox.Dump(e) // [map[birthday:必须是 2006-01-02 格式的日期]]
+		if message := generator(v.default_errors["date"], "birthday", LAYOUT_DATE); len(e) != 1 || e[0]["birthday"] != message {
+			fail(t, fmt.Sprintf("%#v should print error(%s), got %v", value, message, e))
+		}
+	}
+}
+
+// 多个格式
+func Test_Rule_DateValidator_Layouts(t *testing.T) {
+	rules := Rules{"create": {{Attr: "birthday", Rule: "date", Layout: []string{"2006/01/02", "20060102"}}}}
+	if e := v.Validate(rules, M{"birthday": "20240520"}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	e := New().Lang(EN_US).Validate(rules, M{"birthday": "2024-05-20"}, "create")
+	// toolbox.Dump(e) // [map[birthday:must be a date in the format 2006/01/02、20060102]]
+	if want := "must be a date in the format 2006/01/02、20060102"; len(e) != 1 || e[0]["birthday"] != want {
+		fail(t, "should print error("+want+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 绝对边界
+func Test_Rule_DateValidator_Bounds(t *testing.T) {
+	rules := Rules{"create": {{Attr: "birthday", Rule: "date", After: "1900-01-01", Before: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}}}
+	if e := v.Validate(rules, M{"birthday": "1999-12-31"}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	e := v.Validate(rules, M{"birthday": "2000-01-01"}, "create")
+	// toolbox.Dump(e) // [map[birthday:必须早于 2000-01-01]]
+	if message := generator(v.default_errors["before"], "birthday", "2000-01-01"); len(e) != 1 || e[0]["birthday"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+	e = v.Validate(rules, M{"birthday": "1900-01-01"}, "create")
+	// toolbox.Dump(e) // [map[birthday:必须晚于 1900-01-01]]
+	if message := generator(v.default_errors["after"], "birthday", "1900-01-01"); len(e) != 1 || e[0]["birthday"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 相对边界
+func Test_Rule_DateValidator_Relative(t *testing.T) {
+	defer clock()()
+	cases := []struct {
+		bound string
+		value string
+		ok    bool
+	}{
+		{"today", "2024-05-19", true},
+		{"today", "2024-05-20", false},
+		{"tomorrow", "2024-05-20", true},
+		{"yesterday", "2024-05-19", false},
+		{"today+7d", "2024-05-26", true},
+		{"today + 7d", "2024-05-27", false},
+		{"today-1w", "2024-05-12", true},
+		{"today-1mo", "2024-04-20", false},
+		{"Today-1Y", "2023-05-19", true},
+		{"now", "2024-05-20", true},
+		{"now-10h", "2024-05-20", true},
+		{"now-11h", "2024-05-20", false},
+	}
+	for _, c := range cases {
+		rules := Rules{"create": {{Attr: "birthday", Rule: "date", Before: c.bound}}}
+		if e := v.Validate(rules, M{"birthday": c.value}, "create"); (len(e) == 0) != c.ok {
+			fail(t, fmt.Sprintf("%s before %s should be %v, got %v", c.value, c.bound, c.ok, e))
+		}
+	}
+}
+
+// 时区
+func Test_Rule_DateValidator_Timezone(t *testing.T) {
+	defer clock()()
+	// 2024-05-20 10:30:00 UTC 为东京时间 2024-05-20 19:30:00，纽约时间 2024-05-20 06:30:00
+	rules := Rules{"create": {{Attr: "day", Rule: "date", Timezone: "Asia/Tokyo", After: "today"}}}
+	if e := v.Validate(rules, M{"day": "2024-05-20"}, "create"); len(e) != 1 {
+		fail(t, "should print error of day, got "+fmt.Sprint(e))
+	}
+	// 时间戳 1716220800 为 2024-05-20 16:00:00 UTC，东京时间 2024-05-21 01:00:00
+	if e := v.Validate(rules, M{"day": 1716220800}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	rules = Rules{"create": {{Attr: "at", Rule: "datetime", Layout: "2006-01-02 15:04", Timezone: "America/New_York", Before: "now"}}}
+	if e := v.Validate(rules, M{"at": "2024-05-20 06:29"}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if e := v.Validate(rules, M{"at": "2024-05-20 06:30"}, "create"); len(e) != 1 {
+		fail(t, "should print error of at, got "+fmt.Sprint(e))
+	}
+}
+
+/***** datetimeValidator *****/
+
+// RFC 3339
+func Test_Rule_DatetimeValidator(t *testing.T) {
+	defer clock()()
+	rules := Rules{"create": {{Attr: "start_at", Rule: "datetime", After: "now", Before: "now+30d"}}}
+	if e := v.Validate(rules, M{"start_at": "2024-05-21T08:00:00+08:00"}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	e := v.Validate(rules, M{"start_at": "2024-05-21 08:00:00"}, "create")
+	// toolbox.Dump(e) // [map[start_at:必须是 2006-01-02T15:04:05Z07:00 格式的日期时间]]
+	if message := generator(v.default_errors["datetime"], "start_at", time.RFC3339); len(e) != 1 || e[0]["start_at"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+	e = v.Validate(rules, M{"start_at": "2024-05-20T18:00:00+08:00"}, "create")
+	// toolbox.Dump(e) // [map[start_at:必须晚于 2024-05-20T10:30:00Z]]
+	if message := generator(v.default_errors["after"], "start_at", "2024-05-20T10:30:00Z"); len(e) != 1 || e[0]["start_at"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+	e = v.Validate(rules, M{"start_at": time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)}, "create")
+	// toolbox.Dump(e) // [map[start_at:必须早于 2024-06-19T10:30:00Z]]
+	if message := generator(v.default_errors["before"], "start_at", "2024-06-19T10:30:00Z"); len(e) != 1 || e[0]["start_at"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+/***** timeValidator *****/
+
+// 只比较一天中的时间
+func Test_Rule_TimeValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "open_at", Rule: "time", Layout: "15:04", After: "08:00", Before: "22:00"}}}
+	for value, ok := range map[interface{}]bool{
+		"08:00": false,
+		"08:01": true,
+		"21:59": true,
+		"22:00": false,
+		"08-30": false,
+		time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC): true,
+		time.Date(2000, 1, 1, 23, 0, 0, 0, time.UTC): false,
+	} {
+		if e := v.Validate(rules, M{"open_at": value}, "create"); (len(e) == 0) != ok {
+			fail(t, fmt.Sprintf("%v should be valid(%v), got %v", value, ok, e))
+		}
+	}
+	e := New().Lang(EN_US).Validate(rules, M{"open_at": "23:00"}, "create")
+	// toolbox.Dump(e) // [map[open_at:must be before 22:00]]
+	if want := "must be before 22:00"; len(e) != 1 || e[0]["open_at"] != want {
+		fail(t, "should print error("+want+"), got "+fmt.Sprint(e))
+	}
+	if e := v.Validate(Rules{"create": {{Attr: "open_at", Rule: "time"}}}, M{"open_at": "08:00"}, "create"); len(e) != 1 {
+		fail(t, "should print error of open_at, got "+fmt.Sprint(e))
+	}
+}
+
+// 无值
+func Test_Rule_DateValidator_Required(t *testing.T) {
+	rules := Rules{"create": {{Attr: "birthday", Rule: "date"}, {Attr: "open_at", Rule: "time", Required: true}}}
+	e := v.Validate(rules, objEmpty, "create")
+	// toolbox.Dump(e) // [map[open_at:不能为空]]
+	if len(e) != 1 || e[0]["open_at"] != v.default_errors["required"] {
+		fail(t, "should print error of open_at, got "+fmt.Sprint(e))
+	}
+}
+
+// 规则定义错误
+func Test_Rule_DateValidator_RuleErr(t *testing.T) {
+	for _, rule := range []Rule{
+		{Attr: "attr", Rule: "date", Layout: 1},
+		{Attr: "attr", Rule: "date", Layout: []string{}},
+		{Attr: "attr", Rule: "date", Layout: ""},
+		{Attr: "attr", Rule: "date", Timezone: "Mars/Olympus"},
+		{Attr: "attr", Rule: "date", Before: 1},
+		{Attr: "attr", Rule: "date", Before: "next week"},
+		{Attr: "attr", Rule: "date", Before: "now+1x"},
+		{Attr: "attr", Rule: "date", After: "2024-05-20", Before: "2024-05-20"},
+	} {
+		if _, err := v.Compile(Rules{"create": {rule}}); !errors.Is(err, ErrInvalidRuleParam) {
+			fail(t, fmt.Sprintf("%+v should return error(invalid rule param), got %v", rule, err))
+		}
+	}
+}
+
+// 结构体标签
+func Test_Rule_DateValidator_Tag(t *testing.T) {
+	defer clock()()
+	type user struct {
+		Birthday string `json:"birthday" validate:"create:date,layout=2006/01/02,timezone=Asia/Shanghai,after=1900/01/01,before=today"`
+	}
+	rules := MustStructRules(&user{})
+	if e := v.ValidateStruct(rules, &user{Birthday: "2000/01/01"}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if e := v.ValidateStruct(rules, &user{Birthday: "2024/05/20"}, "create"); len(e) != 1 {
+		fail(t, "should print error of birthday, got "+fmt.Sprint(e))
+	}
+}
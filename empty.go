@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Emptiness 空值判定策略，决定 requiredValidator、条件必填验证器及 Rule.Required 如何判定属性无值，后一级策略包含前一级
+// 无值且未设置 Rule.Required 时，其他验证器跳过验证
+type Emptiness int
+
+const (
+	// 未设置，规则使用验证器的策略（见 SetEmptiness），验证器默认为 EMPTY_ABSENT
+	EMPTY_DEFAULT Emptiness = iota
+	// 属性不存在视为无值
+	EMPTY_ABSENT
+	// 属性不存在或为 nil（如 JSON 的 null、nil 指针）视为无值
+	EMPTY_NIL
+	// 同 EMPTY_NIL，去除首尾空白后为空字符串视为无值
+	EMPTY_BLANK
+	// 同 EMPTY_BLANK，空数组、空切片、空对象（M、map）视为无值
+	EMPTY_COLLECTION
+)
+
+// emptiness 策略名，用于结构体标签，如 `validate:"create:required,empty=blank"`
+var emptiness = map[string]Emptiness{
+	"absent":     EMPTY_ABSENT,
+	"nil":        EMPTY_NIL,
+	"blank":      EMPTY_BLANK,
+	"collection": EMPTY_COLLECTION,
+}
+
+// valid 策略是否有效
+func (this Emptiness) valid() bool {
+	return this >= EMPTY_DEFAULT && this <= EMPTY_COLLECTION
+}
+
+// SetEmptiness 设置验证器的空值判定策略，Rule.Empty 优先，无效的策略将 panic
+func (this *validator) SetEmptiness(policy Emptiness) *validator {
+	if policy == EMPTY_DEFAULT || !policy.valid() {
+		panic(&RuleError{Err: ErrInvalidRuleParam, Msg: fmt.Sprintf("invalid emptiness %d", policy)})
+	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	this.emptiness = policy
+	return this
+}
+
+// absent 被验证属性是否无值
+func (this *validator) absent(attr string, rule Rule, obj M) bool {
+	value, ok := obj[attr]
+	return this.empty(value, ok, rule)
+}
+
+// empty 属性是否无值，value、ok 为属性的值及是否存在，策略为 Rule.Empty 或验证器的策略，Rule.Nullable 为 NULL_ABSENT 时 nil 视为无值
+func (this *validator) empty(value interface{}, ok bool, rule Rule) bool {
+	if !ok || value == nil && rule.Nullable == NULL_ABSENT {
+		return true
+	}
+	policy := rule.Empty
+	if policy == EMPTY_DEFAULT {
+		this.mu.RLock()
+		policy = this.emptiness
+		this.mu.RUnlock()
+	}
+	if policy <= EMPTY_ABSENT {
+		return false
+	}
+	// EMPTY_NIL
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return true
+	}
+	if policy == EMPTY_NIL {
+		return false
+	}
+	// EMPTY_BLANK
+	if s, is := value.(string); is {
+		return strings.TrimSpace(s) == ""
+	}
+	if policy == EMPTY_BLANK {
+		return false
+	}
+	// EMPTY_COLLECTION
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
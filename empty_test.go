@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+/***** 空值判定策略 *****/
+
+// 各级策略
+func Test_Emptiness(t *testing.T) {
+	var nilPtr *string
+	cases := []struct {
+		policy Emptiness
+		value  interface{}
+		empty  bool
+	}{
+		{EMPTY_ABSENT, nil, false},
+		{EMPTY_ABSENT, "", false},
+		{EMPTY_NIL, nil, true},
+		{EMPTY_NIL, nilPtr, true},
+		{EMPTY_NIL, "", false},
+		{EMPTY_BLANK, " \t\n", true},
+		{EMPTY_BLANK, nil, true},
+		{EMPTY_BLANK, []interface{}{}, false},
+		{EMPTY_BLANK, " a ", false},
+		{EMPTY_COLLECTION, []interface{}{}, true},
+		{EMPTY_COLLECTION, []string{}, true},
+		{EMPTY_COLLECTION, M{}, true},
+		{EMPTY_COLLECTION, map[string]interface{}{}, true},
+		{EMPTY_COLLECTION, [0]int{}, true},
+		{EMPTY_COLLECTION, "", true},
+		{EMPTY_COLLECTION, []string{""}, false},
+		{EMPTY_COLLECTION, 0, false},
+		{EMPTY_COLLECTION, false, false},
+	}
+	for _, c := range cases {
+		rules := Rules{"create": {{Attr: "attr", Rule: "required", Empty: c.policy}}}
+		e := v.Validate(rules, M{"attr": c.value}, "create")
+		if (len(e) != 0) != c.empty {
+			fail(t, fmt.Sprintf("%#v should be empty(%v) with emptiness %d, got %v", c.value, c.empty, c.policy, e))
+		}
+	}
+	// 不存在总是视为无值
+	if e := v.Validate(Rules{"create": {{Attr: "attr", Rule: "required"}}}, objEmpty, "create"); len(e) != 1 {
+		fail(t, "should print error of attr, got "+fmt.Sprint(e))
+	}
+}
+
+// 验证器的策略作用于所有内置验证器，Rule.Empty 优先
+func Test_SetEmptiness(t *testing.T) {
+	vEmpty := New().SetEmptiness(EMPTY_COLLECTION)
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "required"},
+			{Attr: "nickname", Rule: "string", Min: 3},
+			{Attr: "password", Rule: "string", Min: 6, Required: true},
+			{Attr: "tags", Rule: "each", Required: true, Rules: ScenceRules{{Attr: "name", Rule: "required"}}},
+			{Attr: "address", Rule: "object", Rules: ScenceRules{{Attr: "city", Rule: "required"}}},
+			{Attr: "age", Rule: "int", Required: true, Empty: EMPTY_ABSENT},
+			{Attr: "email", Rule: "required_with", Other: "mobile"},
+			{Attr: "phone", Rule: "required_without", Other: "mobile"},
+			{Attr: "gender", Rule: "in", Enum: []string{"0", "1"}},
+			{Attr: "rpassword", Rule: "same", Other: "password"},
+		},
+	}
+	obj := M{"username": " ", "nickname": "", "password": nil, "tags": []interface{}{}, "address": nil, "age": "", "mobile": "", "gender": nil, "rpassword": ""}
+	e := vEmpty.Validate(rules, obj, "create")
+	// toolbox.Dump(e)
+	want := []E{
+		{"username": v.default_errors["required"]},
+		{"password": v.default_errors["required"]},
+		{"tags": v.default_errors["required"]},
+		{"age": v.default_errors["integer"]},
+		{"phone": generator(v.default_errors["requiredWithout"], "phone", "mobile")},
+	}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// 默认策略不受影响
+	if e := v.Validate(rules, obj, "create"); len(e) <= len(want) {
+		fail(t, "should not affect other validators, got "+fmt.Sprint(e))
+	}
+}
+
+// 无效的策略
+func Test_Emptiness_RuleErr(t *testing.T) {
+	if _, err := v.ValidateE(Rules{"create": {{Attr: "attr", Rule: "required", Empty: 9}}}, objEmpty, "create"); !errors.Is(err, ErrInvalidRuleParam) {
+		fail(t, "should return error(invalid rule param), got "+fmt.Sprint(err))
+	}
+	defer func() {
+		p := recover()
+		if err, ok := p.(error); !ok || !errors.Is(err, ErrInvalidRuleParam) {
+			fail(t, "should panic(invalid emptiness), got "+fmt.Sprint(p))
+		}
+	}()
+	New().SetEmptiness(EMPTY_DEFAULT)
+}
+
+// 结构体标签
+func Test_Emptiness_Tag(t *testing.T) {
+	type user struct {
+		Name string `json:"name" validate:"create:required,empty=blank"`
+	}
+	if e := v.ValidateStruct(MustStructRules(&user{}), &user{Name: "  "}, "create"); len(e) != 1 {
+		fail(t, "should print error of name, got "+fmt.Sprint(e))
+	}
+	if _, err := StructRules(&struct {
+		Name string `validate:"create:required,empty=zero"`
+	}{}); !errors.Is(err, ErrInvalidRule) {
+		fail(t, "should return error(invalid tag), got "+fmt.Sprint(err))
+	}
+}
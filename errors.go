@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 规则定义错误，可使用 errors.Is 判断 *RuleError 的具体类型
+var (
+	// 场景不存在
+	ErrSceneUndefined = errors.New("scence undefined")
+	// 验证器不存在
+	ErrValidatorUndefined = errors.New("validator undefined")
+	// 验证器已存在（AddValidator）
+	ErrValidatorExists = errors.New("validator already exists")
+	// 规则定义错误，Rule.Rule 未定义、Rule.Attr 未定义或类型错误
+	ErrInvalidRule = errors.New("invalid rule")
+	// 规则参数错误，Rule.Max/Rule.Min 类型错误、Rule.Max < Rule.Min、Rule.Enum/Rule.Pattern/Rule.Func 未定义等
+	ErrInvalidRuleParam = errors.New("invalid rule param")
+	// 不支持的语言
+	ErrUnsupportedLang = errors.New("unsupport language")
+	// 语言包错误，格式错误、缺少错误信息（见 RegisterLang）
+	ErrInvalidLang = errors.New("invalid language")
+	// 待验证对象类型错误，如 ValidateStruct 的参数不是结构体或结构体指针
+	ErrInvalidObject = errors.New("invalid object")
+)
+
+// RuleError 规则定义错误，Panic API（Validate 等）以此为 panic 值，Error API（ValidateE 等）以此为返回的 error
+type RuleError struct {
+	// 错误类型，ErrSceneUndefined、ErrValidatorUndefined 等
+	Err error
+	// 出错的规则，与规则无关的错误（如场景不存在）为零值
+	Rule Rule
+	// 错误详情
+	Msg string
+}
+
+// newRuleError 构造与规则相关的 RuleError，错误详情以规则本身开头
+func newRuleError(err error, rule Rule, msg string) *RuleError {
+	return &RuleError{Err: err, Rule: rule, Msg: fmt.Sprint(rule) + " " + msg}
+}
+
+func (this *RuleError) Error() string {
+	return this.Msg
+}
+
+func (this *RuleError) Unwrap() error {
+	return this.Err
+}
+
+// recoverRuleError 将 *RuleError 类型的 panic 转换为 error，其他 panic（如自定义验证函数中的 panic）继续抛出
+func recoverRuleError(err *error) {
+	if p := recover(); p != nil {
+		e, ok := p.(*RuleError)
+		if !ok {
+			panic(p)
+		}
+		*err = e
+	}
+}
@@ -0,0 +1,257 @@
+package validator
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// filter 过滤器函数类型，value、ok 为属性的值及是否有值，返回过滤后的值及是否有值
+type filter func(value interface{}, ok bool, rule Rule) (interface{}, bool)
+
+// tags html 标签
+var tags = regexp.MustCompile(`<[^>]*>`)
+
+// filters 内置过滤器，与验证器一样按规则顺序执行，过滤后的值作用于后续规则，不支持的类型原样保留
+var filters = map[string]filter{
+	"trim":       trimFilter,
+	"lower":      lowerFilter,
+	"upper":      upperFilter,
+	"default":    defaultFilter,
+	"to_int":     toIntFilter,
+	"to_float":   toFloatFilter,
+	"to_bool":    toBoolFilter,
+	"strip_tags": stripTagsFilter,
+}
+
+// ValidateAndClean 场景验证，同 Validate，并返回过滤后的 obj 副本（见 filters），不修改 obj
+// 仅复制过滤器写入的属性路径经过的对象（转换为 M）、数组（转换为 []interface{}），其他值与 obj 共享，保持原类型
+func (this *validator) ValidateAndClean(rules Rules, obj M, scence Scence, opts ...Option) (M, []E) {
+	cleaned, errs := this.exec(this.compile(scenceRulesOf(rules, scence)), obj, newState(scence, append(opts[:len(opts):len(opts)], clean)))
+	return cleaned, errs.E()
+}
+
+// ValidateAndClean 场景验证，同 validator.ValidateAndClean
+func (this *Schema) ValidateAndClean(obj M, scence Scence, opts ...Option) (M, []E) {
+	cleaned, errs := this.validator.exec(this.compiled(scence), obj, newState(scence, append(opts[:len(opts):len(opts)], clean)))
+	return cleaned, errs.E()
+}
+
+// clean 返回过滤后的副本
+func clean(o *options) {
+	o.clean = true
+}
+
+// filtered 规则集（包括嵌套验证规则集）中是否有过滤器
+func filtered(compiled []compiledRule) bool {
+	for _, c := range compiled {
+		if c.filter != nil || filtered(c.children) {
+			return true
+		}
+	}
+	return false
+}
+
+// own 返回 value 的可写副本（浅复制），过滤器只写入副本，不影响原对象，已是副本时原样返回
+// 对象（M、map、结构体）复制为 M，[]interface{} 及其他类型的切片、数组复制为 []interface{}，其他值返回 false
+func (this *state) own(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case time.Time, *time.Time:
+		return value, false
+	case M:
+		if this.owned[identity(v)] {
+			return v, true
+		}
+	case []interface{}:
+		if this.owned[identity(v)] {
+			return v, true
+		}
+		elems := make([]interface{}, len(v))
+		copy(elems, v)
+		return this.mark(elems), true
+	}
+	if rv, ok := array(value); ok {
+		elems := make([]interface{}, rv.Len())
+		for i := range elems {
+			elems[i], _ = normalize(rv.Index(i))
+		}
+		return this.mark(elems), true
+	}
+	if o, ok := object(value); ok {
+		copied := make(M, len(o))
+		for k, v := range o {
+			copied[k] = v
+		}
+		return this.mark(copied), true
+	}
+	return value, false
+}
+
+// mark 记录副本（M 或 []interface{}），见 own
+func (this *state) mark(value interface{}) interface{} {
+	if this.owned == nil {
+		this.owned = make(map[uintptr]bool)
+	}
+	this.owned[identity(value)] = true
+	return value
+}
+
+// identity M、切片的底层地址，用于识别副本
+func identity(value interface{}) uintptr {
+	return reflect.ValueOf(value).Pointer()
+}
+
+// writable 将属性路径 segments（支持通配符）经过的对象、数组替换为可写的副本（见 own），obj 须为副本，其他值保持原样
+func (this *state) writable(obj M, segments []string) {
+	nodes := []interface{}{obj}
+	for _, segment := range segments {
+		next := make([]interface{}, 0, len(nodes))
+		for _, n := range nodes {
+			ks := []string{segment}
+			if segment == PATH_WILDCARD {
+				ks = keys(n)
+			}
+			for _, key := range ks {
+				v, ok := child(n, key)
+				if !ok {
+					continue
+				}
+				if c, ok := this.own(v); ok {
+					put(n, key, c)
+					next = append(next, c)
+				}
+			}
+		}
+		nodes = next
+	}
+}
+
+// writePath 规则写入属性的值时须替换为副本的属性路径，过滤器为末级属性所在的对象，嵌套验证规则集为属性本身（eachValidator 包括每个元素）
+func writePath(c compiledRule, segments []string) []string {
+	switch {
+	case c.filter != nil:
+		return segments[:len(segments)-1]
+	case c.rule.Rule == "each":
+		return append(segments[:len(segments):len(segments)], PATH_WILDCARD)
+	}
+	return segments
+}
+
+// apply 执行过滤器，过滤后的值写回 obj（已替换为副本，见 writable），被过滤属性的展开同验证器（见 traverse）
+func (this *validator) apply(c compiledRule, obj M, st *state, bailed map[string]bool) {
+	traverse(c, obj, st, bailed, func(path string, attr string, o M) {
+		value, ok := o[attr]
+		if value, ok = c.filter(value, ok, c.rule); !ok {
+			return
+		}
+		if path == attr { // 非嵌套属性，o 即 obj
+			obj[attr] = value
+			return
+		}
+		assign(obj, path, value)
+	})
+}
+
+// assign 按具体路径（如 items.3.sku）写入属性的值，中间节点不存在时忽略
+func assign(obj M, path string, value interface{}) {
+	segments := strings.Split(path, PATH_SEPARATOR)
+	last := len(segments) - 1
+	var parent interface{} = obj
+	for _, segment := range segments[:last] {
+		v, ok := child(parent, segment)
+		if !ok {
+			return
+		}
+		parent = v
+	}
+	put(parent, segments[last], value)
+}
+
+// put 写入对象的属性或数组的元素，仅支持副本（M、[]interface{}），下标越界时忽略
+func put(parent interface{}, key string, value interface{}) {
+	switch p := parent.(type) {
+	case M:
+		p[key] = value
+	case []interface{}:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(p) {
+			p[i] = value
+		}
+	}
+}
+
+// trimFilter 去除字符串首尾空白
+func trimFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	if s, is := value.(string); is {
+		return strings.TrimSpace(s), ok
+	}
+	return value, ok
+}
+
+// lowerFilter 字符串转换为小写
+func lowerFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	if s, is := value.(string); is {
+		return strings.ToLower(s), ok
+	}
+	return value, ok
+}
+
+// upperFilter 字符串转换为大写
+func upperFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	if s, is := value.(string); is {
+		return strings.ToUpper(s), ok
+	}
+	return value, ok
+}
+
+// defaultFilter 无值（不存在或为 nil）时使用默认值 Rule.Default
+func defaultFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	if !ok || value == nil {
+		return rule.Default, true
+	}
+	return value, ok
+}
+
+// toIntFilter 整数（整数/无小数位的浮点数/整数字符串）转换为 int64
+func toIntFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	f, is := toNumber(trimmed(value))
+	if !is || f != float64(int64(f)) {
+		return value, ok
+	}
+	return int64(f), ok
+}
+
+// toFloatFilter 数字（整数/浮点数/数字字符串）转换为 float64
+func toFloatFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	if f, is := toNumber(trimmed(value)); is {
+		return f, ok
+	}
+	return value, ok
+}
+
+// trimmed 去除字符串首尾空白，其他类型原样返回
+func trimmed(value interface{}) interface{} {
+	if s, is := value.(string); is {
+		return strings.TrimSpace(s)
+	}
+	return value
+}
+
+// toBoolFilter 字符串表示的布尔值（1、0、t、f、true、false，忽略大小写）转换为 bool
+func toBoolFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	if s, is := value.(string); is {
+		if b, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(s))); err == nil {
+			return b, ok
+		}
+	}
+	return value, ok
+}
+
+// stripTagsFilter 去除字符串中的 html 标签
+func stripTagsFilter(value interface{}, ok bool, rule Rule) (interface{}, bool) {
+	if s, is := value.(string); is {
+		return tags.ReplaceAllString(s, ""), ok
+	}
+	return value, ok
+}
@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+/***** 过滤器 *****/
+
+// 过滤后的值作用于后续规则，Validate 不修改 obj
+func Test_Filter(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "mobile", Rule: "trim"},
+			{Attr: "mobile", Rule: "mobile", Required: true},
+		},
+	}
+	obj := M{"mobile": "  13800138000 "}
+	if e := v.Validate(rules, obj, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if obj["mobile"] != "  13800138000 " {
+		fail(t, "should not modify obj, got "+fmt.Sprint(obj["mobile"]))
+	}
+	// 过滤器按规则顺序执行，之前的规则使用原值
+	rules["create"][0], rules["create"][1] = rules["create"][1], rules["create"][0]
+	if e := v.Validate(rules, obj, "create"); len(e) != 1 {
+		fail(t, "should print error of mobile, got "+fmt.Sprint(e))
+	}
+}
+
+// 内置过滤器
+func Test_Filter_Builtin(t *testing.T) {
+	cases := []struct {
+		rule      Rule
+		value     interface{}
+		present   bool
+		want      interface{}
+		wantExist bool
+	}{
+		{Rule{Rule: "trim"}, " a b\t", true, "a b", true},
+		{Rule{Rule: "trim"}, 1, true, 1, true},
+		{Rule{Rule: "trim"}, nil, false, nil, false},
+		{Rule{Rule: "lower"}, "HyB", true, "hyb", true},
+		{Rule{Rule: "upper"}, "HyB", true, "HYB", true},
+		{Rule{Rule: "default", Default: "male"}, nil, false, "male", true},
+		{Rule{Rule: "default", Default: "male"}, nil, true, "male", true},
+		{Rule{Rule: "default", Default: "male"}, "", true, "", true},
+		{Rule{Rule: "to_int"}, " 18 ", true, int64(18), true},
+		{Rule{Rule: "to_int"}, float64(18), true, int64(18), true},
+		{Rule{Rule: "to_int"}, "18.5", true, "18.5", true},
+		{Rule{Rule: "to_int"}, "abc", true, "abc", true},
+		{Rule{Rule: "to_float"}, "18.5", true, 18.5, true},
+		{Rule{Rule: "to_float"}, int32(18), true, float64(18), true},
+		{Rule{Rule: "to_bool"}, "TRUE", true, true, true},
+		{Rule{Rule: "to_bool"}, "0", true, false, true},
+		{Rule{Rule: "to_bool"}, "yes", true, "yes", true},
+		{Rule{Rule: "strip_tags"}, "<b>hyb</b><br/>", true, "hyb", true},
+	}
+	for _, c := range cases {
+		c.rule.Attr = "attr"
+		obj := M{}
+		if c.present {
+			obj["attr"] = c.value
+		}
+		cleaned, e := v.ValidateAndClean(Rules{"create": {c.rule}}, obj, "create")
+		value, ok := cleaned["attr"]
+		if len(e) != 0 || ok != c.wantExist || !reflect.DeepEqual(value, c.want) {
+			fail(t, c.rule.Rule+" "+fmt.Sprint(c.value)+" should be "+fmt.Sprint(c.want)+", got "+fmt.Sprint(value, e))
+		}
+	}
+}
+
+/***** ValidateAndClean() *****/
+
+// 返回过滤后的副本，嵌套属性路径、嵌套验证规则集同样过滤
+func Test_ValidateAndClean(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: []string{"username", "email"}, Rule: "trim"},
+			{Attr: "email", Rule: "lower"},
+			{Attr: "email", Rule: "email"},
+			{Attr: "age", Rule: "to_int"},
+			{Attr: "age", Rule: "int", Min: 18},
+			{Attr: "gender", Rule: "default", Default: "0"},
+			{Attr: "address.city", Rule: "upper"},
+			{Attr: "tags.*", Rule: "trim"},
+			{Attr: "items", Rule: "each", Rules: ScenceRules{
+				{Attr: "count", Rule: "to_int"},
+				{Attr: "count", Rule: "int", Symbol: 1},
+			}},
+		},
+	}
+	obj := M{
+		"username": " hyb ",
+		"email":    " HYB76788424@163.COM",
+		"age":      "18",
+		"address":  map[string]interface{}{"city": "hz"},
+		"tags":     []string{" a ", "b "},
+		"items":    []interface{}{M{"count": "2"}, M{"count": float64(1)}},
+		"remark":   "<b>",
+	}
+	want := M{
+		"username": "hyb",
+		"email":    "hyb76788424@163.com",
+		"age":      int64(18),
+		"gender":   "0",
+		"address":  M{"city": "HZ"},
+		"tags":     []interface{}{"a", "b"},
+		"items":    []interface{}{M{"count": int64(2)}, M{"count": int64(1)}},
+		"remark":   "<b>",
+	}
+	cleaned, e := v.ValidateAndClean(rules, obj, "create")
+	// toolbox.Dump(cleaned)
+	if len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if !reflect.DeepEqual(cleaned, want) {
+		fail(t, "should return "+fmt.Sprint(want)+", got "+fmt.Sprint(cleaned))
+	}
+	if obj["age"] != "18" || obj["address"].(map[string]interface{})["city"] != "hz" || obj["items"].([]interface{})[0].(M)["count"] != "2" {
+		fail(t, "should not modify obj, got "+fmt.Sprint(obj))
+	}
+	if cleaned, _ := v.MustCompile(rules).ValidateAndClean(obj, "create"); !reflect.DeepEqual(cleaned, want) {
+		fail(t, "should return "+fmt.Sprint(want)+", got "+fmt.Sprint(cleaned))
+	}
+}
+
+// 验证失败时同样返回过滤后的副本
+func Test_ValidateAndClean_Failed(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "age", Rule: "to_int"},
+			{Attr: "age", Rule: "int", Min: 18},
+		},
+	}
+	cleaned, e := v.ValidateAndClean(rules, M{"age": " 17 "}, "create")
+	if len(e) != 1 || cleaned["age"] != int64(17) {
+		fail(t, "should print error of age and return age 17, got "+fmt.Sprint(cleaned, e))
+	}
+}
+
+// 仅复制过滤器写入的属性路径经过的对象、数组，其他值保持原类型
+func Test_ValidateAndClean_Types(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "name", Rule: "trim"},
+			{Attr: "items.*.sku", Rule: "upper"},
+			{Attr: "tags", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				if _, ok := obj[attr].([]string); !ok {
+					return E{attr: fmt.Sprintf("%T", obj[attr])}
+				}
+				return nil
+			}},
+		},
+	}
+	obj := M{"name": " hyb ", "tags": []string{"a"}, "address": Address{City: "hz"}, "items": []Item{{Sku: "a001"}}}
+	cleaned, e := v.ValidateAndClean(rules, obj, "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if _, ok := cleaned["tags"].([]string); !ok {
+		fail(t, "should keep the type of tags, got "+fmt.Sprintf("%T", cleaned["tags"]))
+	}
+	if _, ok := cleaned["address"].(Address); !ok {
+		fail(t, "should keep the type of address, got "+fmt.Sprintf("%T", cleaned["address"]))
+	}
+	if want := (M{"sku": "A001", "count": 0}); !reflect.DeepEqual(cleaned["items"], []interface{}{want}) {
+		fail(t, "should return items "+fmt.Sprint(want)+", got "+fmt.Sprint(cleaned["items"]))
+	}
+	if cleaned["name"] != "hyb" || obj["name"] != " hyb " || obj["items"].([]Item)[0].Sku != "a001" {
+		fail(t, "should not modify obj, got "+fmt.Sprint(obj))
+	}
+}
+
+// 不修改调用方的选项切片
+func Test_ValidateAndClean_Options(t *testing.T) {
+	opts := make([]Option, 1, 2)
+	opts[0] = FailFast()
+	v.ValidateAndClean(Rules{"create": {{Attr: "age", Rule: "to_int"}}}, M{"age": "18"}, "create", opts...)
+	v.MustCompile(Rules{"create": {{Attr: "age", Rule: "to_int"}}}).ValidateAndClean(M{"age": "18"}, "create", opts...)
+	if opts[:2][1] != nil {
+		fail(t, "should not write into the backing array of opts")
+	}
+}
+
+// 规则定义错误，过滤器名不能用作验证器名
+func Test_Filter_RuleErr(t *testing.T) {
+	if _, err := v.ValidateE(Rules{"create": {{Attr: "gender", Rule: "default"}}}, objEmpty, "create"); !errors.Is(err, ErrInvalidRuleParam) {
+		fail(t, "should return error(invalid rule param), got "+fmt.Sprint(err))
+	}
+	defer func() {
+		p := recover()
+		if err, ok := p.(error); !ok || !errors.Is(err, ErrValidatorExists) {
+			fail(t, "should panic(filter named 'trim' already exists), got "+fmt.Sprint(p))
+		}
+	}()
+	New().AddValidator("trim", func(attr string, rule Rule, obj M) E { return nil })
+}
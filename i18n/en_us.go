@@ -5,63 +5,114 @@ const EN_US = "EN_US"
 func init() {
     Errors[EN_US] = errors{
          // common
-        "equal": "must be euqal to %v",
+        "equal": "must be euqal to {max}",
+        // Strict
+        "unknown": "unknown field",
         // requiredValidator
         "required": "can not be empty",
+        // notNullValidator
+        "notNull": "can not be null",
+        // requiredIfValidator
+        "requiredIf": "can not be empty when {other} is in {enum}",
+        // requiredUnlessValidator
+        "requiredUnless": "can not be empty unless {other} is in {enum}",
+        // requiredWithValidator
+        "requiredWith": "can not be empty when {other} is present",
+        // requiredWithoutValidator
+        "requiredWithout": "can not be empty when {other} is not present",
+        // sameValidator
+        "same": "must be the same as {other}",
+        // differentValidator
+        "different": "must be different from {other}",
+        // gtFieldValidator, gteFieldValidator, ltFieldValidator, lteFieldValidator
+        "gtField": "must be greater than {other}",
+        "gteField": "must be greater than or equal to {other}",
+        "ltField": "must be less than {other}",
+        "lteField": "must be less than or equal to {other}",
+        "compare": "can not be compared with {other}",
         // inValidator
-        "in": "must be in %v",
+        "in": "must be in {enum}",
         "inValid": "must be one of string, number, boolean",
         // stringValidator
         "string": "must be a string",
-        "stringLengthMax": "{label}'s maximum length is %v",
-        "stringLengthMin": "{label}'s minimum length is %v",
-        "stringLengthRange": "{label}'s length is %v to %v",
-        "stringLengthEqual": "{label}'s length must be equal to %v",
+        "stringLengthMax": "{label}'s maximum length is {max}",
+        "stringLengthMin": "{label}'s minimum length is {min}",
+        "stringLengthRange": "{label}'s length is {min} to {max}",
+        "stringLengthEqual": "{label}'s length must be equal to {max}",
         // intValidator
         "integer": "must be an integer",
-        "integerMax": "must be an integer with a maximum value of %v",
-        "integerMin": "must be an integer with a minimum value of %v",
-        "integerRange": "must be an integer of %v to %v",
+        "integerMax": "must be an integer with a maximum value of {max}",
+        "integerMin": "must be an integer with a minimum value of {min}",
+        "integerRange": "must be an integer of {min} to {max}",
         "integerPositive": "must be a positive integer",
-        "integerPositiveMax": "must be a positive integer with a maximum value of %v",
-        "integerPositiveMin": "must be a positive integer with a minimum value of %v",
-        "integerPositiveRange": "must be a positive integer of %v to %v",
+        "integerPositiveMax": "must be a positive integer with a maximum value of {max}",
+        "integerPositiveMin": "must be a positive integer with a minimum value of {min}",
+        "integerPositiveRange": "must be a positive integer of {min} to {max}",
         "integerNegative": "must be a negative integer",
-        "integerNegativeMax": "must be a negative integer with a maximum value of %v",
-        "integerNegativeMin": "must be a negative integer with a minimum value of %v",
-        "integerNegativeRange": "must be a negative integer of %v to %v",
+        "integerNegativeMax": "must be a negative integer with a maximum value of {max}",
+        "integerNegativeMin": "must be a negative integer with a minimum value of {min}",
+        "integerNegativeRange": "must be a negative integer of {min} to {max}",
         // floatValidator
         "decimal": "must be a decimal",
-        "decimalMax": "must be a decimal with a maximum value of %v",
-        "decimalMin": "must be a decimal with a minimum value of %v",
-        "decimalRange": "must be a decimal of %v to %v",
+        "decimalMax": "must be a decimal with a maximum value of {max}",
+        "decimalMin": "must be a decimal with a minimum value of {min}",
+        "decimalRange": "must be a decimal of {min} to {max}",
         "decimalPositive": "must be a positive decimal",
-        "decimalPositiveMax": "must be a positive decimal with a maximum value of %v",
-        "decimalPositiveMin": "must be a positive decimal with a minimum value of %v",
-        "decimalPositiveRange": "must be a positive decimal of %v to %v",
+        "decimalPositiveMax": "must be a positive decimal with a maximum value of {max}",
+        "decimalPositiveMin": "must be a positive decimal with a minimum value of {min}",
+        "decimalPositiveRange": "must be a positive decimal of {min} to {max}",
         "decimalNegative": "must be a negative decimal",
-        "decimalNegativeMax": "must be a negative decimal with a maximum value of %v",
-        "decimalNegativeMin": "must be a negative decimal with a minimum value of %v",
-        "decimalNegativeRange": "must be a negative decimal of %v to %v",
+        "decimalNegativeMax": "must be a negative decimal with a maximum value of {max}",
+        "decimalNegativeMin": "must be a negative decimal with a minimum value of {min}",
+        "decimalNegativeRange": "must be a negative decimal of {min} to {max}",
         // numberValidator
         "number": "must be a number",
-        "numberMax": "must be a number with a maximum value of %v",
-        "numberMin": "must be a number with a minimum value of %v",
-        "numberRange": "must be a number of %v to %v",
+        "numberMax": "must be a number with a maximum value of {max}",
+        "numberMin": "must be a number with a minimum value of {min}",
+        "numberRange": "must be a number of {min} to {max}",
         "numberPositive": "must be a positive number",
-        "numberPositiveMax": "must be a positive number with a maximum value of %v",
-        "numberPositiveMin": "must be a positive number with a minimum value of %v",
-        "numberPositiveRange": "must be a positive number of %v to %v",
+        "numberPositiveMax": "must be a positive number with a maximum value of {max}",
+        "numberPositiveMin": "must be a positive number with a minimum value of {min}",
+        "numberPositiveRange": "must be a positive number of {min} to {max}",
         "numberNegative": "must be a negative number",
-        "numberNegativeMax": "must be a negative number with a maximum value of %v",
-        "numberNegativeMin": "must be a negative number with a minimum value of %v",
-        "numberNegativeRange": "must be a negative number of %v to %v",
+        "numberNegativeMax": "must be a negative number with a maximum value of {max}",
+        "numberNegativeMin": "must be a negative number with a minimum value of {min}",
+        "numberNegativeRange": "must be a negative number of {min} to {max}",
         // booleanValidator
         "boolean": "must be a boolean or string",
         // regexValidator
         "regex": "must be in a valid format",
+        // dateValidator、datetimeValidator、timeValidator
+        "date": "must be a date in the format {layout}",
+        "datetime": "must be a datetime in the format {layout}",
+        "time": "must be a time in the format {layout}",
+        "before": "must be before {before}",
+        "after": "must be after {after}",
         // ipValidator
         "ip": "must be a valid ip address",
+        // ipv4Validator、ipv6Validator
+        "ipv4": "must be a valid ipv4 address",
+        "ipv6": "must be a valid ipv6 address",
+        "ipNetwork": "must be in one of the networks {enum}",
+        "ipClass": "must be one of the address types {enum}",
+        // cidrValidator
+        "cidr": "must be a valid CIDR",
+        // macValidator
+        "mac": "must be a valid MAC address",
+        // hostnameValidator
+        "hostname": "must be a valid hostname",
+        // portValidator、hostPortValidator
+        "port": "must be a valid port",
+        "portRange": "must be a port in {enum}",
+        "hostPort": "must be a valid host:port",
+        // urlValidator
+        "url": "must be a valid url",
+        "urlScheme": "must use one of the schemes {enum}",
+        "urlHost": "must contain a host",
+        "urlHostNotAllowed": "must use an allowed host",
+        "urlHostBlocked": "must not use a blocked host",
+        "urlPort": "must use a port in {enum}",
+        "urlPrivate": "must not point to a private, loopback or link-local address",
         // emailValidator
         "email": "must be a valid email address",
         // telValidator
@@ -70,5 +121,9 @@ func init() {
         "mobile": "must be a valid telephone or mobile phone number",
         // zipcodeValidator
         "zipcode": "must be a valid zipcode",
+        // objectValidator
+        "object": "must be an object",
+        // eachValidator
+        "each": "must be an array",
     }
 }
\ No newline at end of file
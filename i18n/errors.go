@@ -1,5 +1,6 @@
 package i18n
 
+// errors 错误信息模板，键为错误信息的键，值使用命名占位符，如 {label}、{attr}、{min}、{max}、{enum}、{value}、{other}、{layout}、{before}、{after}
 type errors map[string]string
 
 var Errors = make(map[string]errors)
\ No newline at end of file
@@ -5,63 +5,114 @@ const ZH_CN = "ZH_CN"
 func init() {
     Errors[ZH_CN] = errors{
         // common
-        "equal": "必须是 %v",
+        "equal": "必须是 {max}",
+        // Strict
+        "unknown": "未定义的属性",
         // requiredValidator
         "required": "不能为空",
+        // notNullValidator
+        "notNull": "不能为 null",
+        // requiredIfValidator
+        "requiredIf": "{other} 为 {enum} 中的一个时不能为空",
+        // requiredUnlessValidator
+        "requiredUnless": "{other} 不为 {enum} 中的一个时不能为空",
+        // requiredWithValidator
+        "requiredWith": "{other} 存在时不能为空",
+        // requiredWithoutValidator
+        "requiredWithout": "{other} 不存在时不能为空",
+        // sameValidator
+        "same": "必须与 {other} 相同",
+        // differentValidator
+        "different": "不能与 {other} 相同",
+        // gtFieldValidator、gteFieldValidator、ltFieldValidator、lteFieldValidator
+        "gtField": "必须大于 {other}",
+        "gteField": "必须大于或等于 {other}",
+        "ltField": "必须小于 {other}",
+        "lteField": "必须小于或等于 {other}",
+        "compare": "无法与 {other} 比较",
         // inValidator
-        "in": "只能是 %v 中的一个",
+        "in": "只能是 {enum} 中的一个",
         "inValid": "必须是字符串、数字、布尔值中的一种",
         // stringValidator
         "string": "必须是字符串",
-        "stringLengthMax": "长度不能超过 %v",
-        "stringLengthMin": "长度不能小于 %v",
-        "stringLengthRange": "长度必须在 %v 到 %v 之间",
-        "stringLengthEqual": "长度必须是 %v",
+        "stringLengthMax": "长度不能超过 {max}",
+        "stringLengthMin": "长度不能小于 {min}",
+        "stringLengthRange": "长度必须在 {min} 到 {max} 之间",
+        "stringLengthEqual": "长度必须是 {max}",
         // intValidator
         "integer": "必须是整数",
-        "integerMax": "必须是不大于 %v 的整数",
-        "integerMin": "必须是不小于 %v 的整数",
-        "integerRange": "必须是介于 %v 到 %v 的整数",
+        "integerMax": "必须是不大于 {max} 的整数",
+        "integerMin": "必须是不小于 {min} 的整数",
+        "integerRange": "必须是介于 {min} 到 {max} 的整数",
         "integerPositive": "必须是正整数",
-        "integerPositiveMax": "必须是不大于 %v 的正整数",
-        "integerPositiveMin": "必须是不小于 %v 的正整数",
-        "integerPositiveRange": "必须是介于 %v 到 %v 的正整数",
+        "integerPositiveMax": "必须是不大于 {max} 的正整数",
+        "integerPositiveMin": "必须是不小于 {min} 的正整数",
+        "integerPositiveRange": "必须是介于 {min} 到 {max} 的正整数",
         "integerNegative": "必须是负整数",
-        "integerNegativeMax": "必须是不大于 %v 的负整数",
-        "integerNegativeMin": "必须是不小于 %v 的负整数",
-        "integerNegativeRange": "必须是介于 %v 到 %v 的负整数",
+        "integerNegativeMax": "必须是不大于 {max} 的负整数",
+        "integerNegativeMin": "必须是不小于 {min} 的负整数",
+        "integerNegativeRange": "必须是介于 {min} 到 {max} 的负整数",
         // floatValidator
         "decimal": "必须是小数",
-        "decimalMax": "必须是不大于 %v 的小数",
-        "decimalMin": "必须是不小于 %v 的小数",
-        "decimalRange": "必须是介于 %v 到 %v 的小数",
+        "decimalMax": "必须是不大于 {max} 的小数",
+        "decimalMin": "必须是不小于 {min} 的小数",
+        "decimalRange": "必须是介于 {min} 到 {max} 的小数",
         "decimalPositive": "必须是正小数",
-        "decimalPositiveMax": "必须是不大于 %v 的正小数",
-        "decimalPositiveMin": "必须是不小于 %v 的正小数",
-        "decimalPositiveRange": "必须是介于 %v 到 %v 的正小数",
+        "decimalPositiveMax": "必须是不大于 {max} 的正小数",
+        "decimalPositiveMin": "必须是不小于 {min} 的正小数",
+        "decimalPositiveRange": "必须是介于 {min} 到 {max} 的正小数",
         "decimalNegative": "必须是负小数",
-        "decimalNegativeMax": "必须是不大于 %v 的负小数",
-        "decimalNegativeMin": "必须是不小于 %v 的负小数",
-        "decimalNegativeRange": "必须是介于 %v 到 %v 的负小数",
+        "decimalNegativeMax": "必须是不大于 {max} 的负小数",
+        "decimalNegativeMin": "必须是不小于 {min} 的负小数",
+        "decimalNegativeRange": "必须是介于 {min} 到 {max} 的负小数",
         // numberValidator
         "number": "必须是数字",
-        "numberMax": "必须是不大于 %v 的数",
-        "numberMin": "必须是不小于 %v 的数",
-        "numberRange": "必须是介于 %v 到 %v 的数",
+        "numberMax": "必须是不大于 {max} 的数",
+        "numberMin": "必须是不小于 {min} 的数",
+        "numberRange": "必须是介于 {min} 到 {max} 的数",
         "numberPositive": "必须是正数",
-        "numberPositiveMax": "必须是不大于 %v 的正数",
-        "numberPositiveMin": "必须是不小于 %v 的正数",
-        "numberPositiveRange": "必须是介于 %v 到 %v 的正数",
+        "numberPositiveMax": "必须是不大于 {max} 的正数",
+        "numberPositiveMin": "必须是不小于 {min} 的正数",
+        "numberPositiveRange": "必须是介于 {min} 到 {max} 的正数",
         "numberNegative": "必须是负数",
-        "numberNegativeMax": "必须是不大于 %v 的负数",
-        "numberNegativeMin": "必须是不小于 %v 的负数",
-        "numberNegativeRange": "必须是介于 %v 到 %v 的负数",
+        "numberNegativeMax": "必须是不大于 {max} 的负数",
+        "numberNegativeMin": "必须是不小于 {min} 的负数",
+        "numberNegativeRange": "必须是介于 {min} 到 {max} 的负数",
         // booleanValidator
         "boolean": "必须是布尔值或布尔字符串",
         // regexValidator
         "regex": "格式不正确",
+        // dateValidator、datetimeValidator、timeValidator
+        "date": "必须是 {layout} 格式的日期",
+        "datetime": "必须是 {layout} 格式的日期时间",
+        "time": "必须是 {layout} 格式的时间",
+        "before": "必须早于 {before}",
+        "after": "必须晚于 {after}",
         // ipValidator
         "ip": "无效的 ip",
+        // ipv4Validator、ipv6Validator
+        "ipv4": "无效的 ipv4",
+        "ipv6": "无效的 ipv6",
+        "ipNetwork": "必须属于 {enum} 中的一个网段",
+        "ipClass": "地址类型只能是 {enum} 中的一个",
+        // cidrValidator
+        "cidr": "无效的 CIDR",
+        // macValidator
+        "mac": "无效的 MAC 地址",
+        // hostnameValidator
+        "hostname": "无效的主机名",
+        // portValidator、hostPortValidator
+        "port": "无效的端口",
+        "portRange": "端口必须在 {enum} 范围内",
+        "hostPort": "必须是有效的 主机:端口",
+        // urlValidator
+        "url": "无效的 url",
+        "urlScheme": "协议只能是 {enum} 中的一个",
+        "urlHost": "缺少主机",
+        "urlHostNotAllowed": "主机不在允许的范围内",
+        "urlHostBlocked": "主机已被禁止",
+        "urlPort": "端口必须在 {enum} 范围内",
+        "urlPrivate": "不能是内网、回环或链路本地地址",
         // emailValidator
         "email": "无效的 email",
         // telValidator
@@ -70,5 +121,9 @@ func init() {
         "mobile": "无效的手机号",
         // zipcodeValidator
         "zipcode": "无效的邮编",
+        // objectValidator
+        "object": "必须是对象",
+        // eachValidator
+        "each": "必须是数组",
     }
 }
\ No newline at end of file
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SetLabels 设置语言的属性显示名称（标签），键为属性名或属性路径，如 username、address.city、items.*.sku
+// 替换错误信息（内置错误信息及 Rule.Message）中的 {label}，Rule.Label 优先，未设置标签时为属性路径；{other} 同样替换为其他属性的标签
+// 多次调用合并标签，不支持的语言将 panic
+func (this *validator) SetLabels(lang string, labels map[string]string) *validator {
+	l := strings.ToUpper(lang)
+	if _, ok := catalogueOf(l); !ok {
+		panic(&RuleError{Err: ErrUnsupportedLang, Msg: lang + " unsupport language"})
+	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.labels == nil {
+		this.labels = make(map[string]map[string]string)
+	}
+	if this.labels[l] == nil {
+		this.labels[l] = make(map[string]string, len(labels))
+	}
+	for attr, label := range labels {
+		this.labels[l][attr] = label
+	}
+	return this
+}
+
+// labelOf 属性的标签，依次查找 Rule.Label、具体路径（items.3.sku）、通配路径（items.*.sku）、末级属性名（sku），均未设置时为具体路径
+func (this *validator) labelOf(e *ValidationError, labels map[string]string) string {
+	if e.label != "" {
+		return e.label
+	}
+	if label, ok := findLabel(e.Attr, labels); ok {
+		return label
+	}
+	return e.Attr
+}
+
+// othersLabel 被比较的其他属性（Rule.Other）的标签，多个以 、 分隔
+// 其他属性相对于被验证属性所在的对象，按其具体路径查找标签（同 labelOf），未设置时为 Rule.Other 中的属性路径
+func othersLabel(e *ValidationError, labels map[string]string) string {
+	parent := ""
+	if i := strings.LastIndex(e.Attr, PATH_SEPARATOR); i >= 0 {
+		parent = e.Attr[:i+1]
+	}
+	names := make([]string, 0, len(e.others))
+	for _, other := range e.others {
+		label, ok := findLabel(parent+other, labels)
+		if !ok {
+			label = other
+		}
+		names = append(names, label)
+	}
+	return strings.Join(names, "、")
+}
+
+// findLabel 按具体路径、通配路径、末级属性名依次查找属性路径的标签
+func findLabel(path string, labels map[string]string) (string, bool) {
+	if label, ok := labels[path]; ok {
+		return label, true
+	}
+	segments := strings.Split(path, PATH_SEPARATOR)
+	if len(segments) == 1 {
+		return "", false
+	}
+	wildcard := make([]string, len(segments))
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			segment = PATH_WILDCARD
+		}
+		wildcard[i] = segment
+	}
+	if label, ok := labels[strings.Join(wildcard, PATH_SEPARATOR)]; ok {
+		return label, true
+	}
+	label, ok := labels[segments[len(segments)-1]]
+	return label, ok
+}
@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+/***** {label} *****/
+
+// 未设置标签时，{label} 替换为属性路径
+func Test_Label_Default(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "string", Max: 3},
+			{Attr: "address.city", Rule: "string", Min: 3},
+		},
+	}
+	e := New().Lang(EN_US).Validate(rules, M{"username": "hyb123", "address": M{"city": "hz"}}, "create")
+	// toolbox.Dump(e) // [map[username:username's maximum length is 3] map[address.city:address.city's minimum length is 3]]
+	if len(e) != 2 || e[0]["username"] != "username's maximum length is 3" || e[1]["address.city"] != "address.city's minimum length is 3" {
+		fail(t, "should replace {label} with attribute, got "+fmt.Sprint(e))
+	}
+}
+
+// Rule.Label 优先于 SetLabels，标签按具体路径、通配路径、末级属性名查找
+func Test_Label(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "string", Max: 3},
+			{Attr: "password", Rule: "string", Max: 3, Label: "Secret"},
+			{Attr: "items", Rule: "each", Rules: ScenceRules{
+				{Attr: "sku", Rule: "string", Max: 3},
+				{Attr: "name", Rule: "string", Max: 3},
+			}},
+			{Attr: "tags.*", Rule: "string", Max: 3},
+		},
+	}
+	vEnglish := New().Lang(EN_US).SetLabels(EN_US, map[string]string{
+		"username":    "Username",
+		"password":    "Password",
+		"items.*.sku": "SKU",
+		"name":        "Name",
+	}).SetLabels("en_us", map[string]string{"tags.0": "First tag"})
+	obj := M{"username": "hyb123", "password": "******", "items": []interface{}{M{"sku": "abcd", "name": "abcd"}}, "tags": []string{"abcd", "abcd"}}
+	e := vEnglish.Validate(rules, obj, "create")
+	// toolbox.Dump(e)
+	want := []E{
+		{"username": "Username's maximum length is 3"},
+		{"password": "Secret's maximum length is 3"},
+		{"items.0.sku": "SKU's maximum length is 3"},
+		{"items.0.name": "Name's maximum length is 3"},
+		{"tags.0": "First tag's maximum length is 3"},
+		{"tags.1": "tags.1's maximum length is 3"},
+	}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// 其他语言的标签不生效
+	if e := New().SetLabels(EN_US, map[string]string{"username": "Username"}).Validate(rules, obj, "create"); e[0]["username"] != generator(v.default_errors["stringLengthMax"], "username", 3) {
+		fail(t, "should ignore labels of other languages, got "+fmt.Sprint(e[0]))
+	}
+}
+
+// 自定义错误信息、自定义验证器、generator 同样替换 {label}
+func Test_Label_Custom(t *testing.T) {
+	vLabel := New().SetLabels(ZH_CN, map[string]string{"username": "用户名", "age": "年龄"})
+	vLabel.AddValidator("adult", func(attr string, rule Rule, obj M) E {
+		if obj[attr] == 18 {
+			return E{attr: "{label}必须大于 18"}
+		}
+		if obj[attr] == 17 {
+			rule.Message = "{label}未成年"
+			return vLabel.generator("required", attr, rule)
+		}
+		return nil
+	})
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "required", Message: "{label}不能为空"},
+			{Attr: "age", Rule: "adult"},
+			{Attr: "child", Rule: "adult", Label: "儿童"},
+		},
+	}
+	e := vLabel.Validate(rules, M{"age": 18, "child": 17}, "create")
+	// toolbox.Dump(e) // [map[username:用户名不能为空] map[age:年龄必须大于 18] map[child:儿童未成年]]
+	want := []E{{"username": "用户名不能为空"}, {"age": "年龄必须大于 18"}, {"child": "儿童未成年"}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+}
+
+// 结构体标签
+func Test_Label_Tag(t *testing.T) {
+	type user struct {
+		Name string `json:"name" validate:"create:required,label=用户名,message='{label}不能为空'"`
+	}
+	e := v.ValidateStruct(MustStructRules(&user{}), &struct{}{}, "create")
+	if len(e) != 1 || e[0]["name"] != "用户名不能为空" {
+		fail(t, "should print error(用户名不能为空), got "+fmt.Sprint(e))
+	}
+}
+
+// {other} 替换为其他属性的标签，按其具体路径查找
+func Test_Label_Other(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "rpassword", Rule: "same", Other: "password", Label: "确认密码"},
+			{Attr: "nickname", Rule: "required_with", Other: []string{"username", "email"}},
+			{Attr: "items.*.max", Rule: "gt_field", Other: "min"},
+		},
+	}
+	vLabel := New().SetLabels(ZH_CN, map[string]string{"password": "密码", "username": "用户名", "items.*.min": "最小值"})
+	e := vLabel.Validate(rules, M{"password": "a", "rpassword": "b", "username": "hyb", "items": []interface{}{M{"min": 2, "max": 1}}}, "create")
+	// toolbox.Dump(e) // [map[rpassword:必须与 密码 相同] map[nickname:用户名、email 存在时不能为空] map[items.0.max:必须大于 最小值]]
+	want := []E{
+		{"rpassword": generator(vLabel.default_errors["same"], "确认密码", "密码")},
+		{"nickname": generator(vLabel.default_errors["requiredWith"], "nickname", "用户名、email")},
+		{"items.0.max": generator(vLabel.default_errors["gtField"], "items.0.max", "最小值")},
+	}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	errs, _ := vLabel.Check(rules, M{"password": "a", "rpassword": "b"}, "create").(Errors)
+	if len(errs) == 0 || errs[0].Params["other"] != "password" {
+		fail(t, "should keep attribute path in params, got "+fmt.Sprint(errs))
+	}
+}
+
+// 不支持的语言
+func Test_Label_Undefined_Lang(t *testing.T) {
+	defer func() {
+		p := recover()
+		if err, ok := p.(error); !ok || !errors.Is(err, ErrUnsupportedLang) {
+			fail(t, "should panic(unsupport language), got "+fmt.Sprint(p))
+		}
+	}()
+	New().SetLabels("fr", map[string]string{"username": "nom"})
+}
@@ -0,0 +1,248 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/goindow/validator/i18n"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 语言包格式
+const (
+	FORMAT_JSON = "json"
+	FORMAT_YAML = "yaml"
+)
+
+// 语言包中的父语言，缺少的错误信息使用父语言的错误信息，如 {"extends": "en_us"}
+const LANG_EXTENDS = "extends"
+
+// langs 读写锁，保护 i18n.Errors，运行时可注册语言包
+var langs sync.RWMutex
+
+// catalogueOf 语言的错误信息，lang 为大写的语言名
+func catalogueOf(lang string) (map[string]string, bool) {
+	langs.RLock()
+	defer langs.RUnlock()
+	catalogue, ok := i18n.Errors[lang]
+	return catalogue, ok
+}
+
+// RegisterLang 注册语言包，语言名不区分大小写，已存在的语言将被替换（已使用该语言的验证器需重新调用 Lang）
+// messages 中 extends 为父语言，缺少的错误信息使用父语言的错误信息
+// 合并父语言后必须包含 DEFAULT_LANG 的所有错误信息的键，否则返回 ErrInvalidLang，父语言不存在返回 ErrUnsupportedLang
+func RegisterLang(name string, messages map[string]string) error {
+	lang := strings.ToUpper(name)
+	if lang == "" {
+		return &RuleError{Err: ErrInvalidLang, Msg: "language name not found"}
+	}
+	catalogue := make(map[string]string, len(messages))
+	// 父语言
+	if parent, ok := messages[LANG_EXTENDS]; ok {
+		p, ok := catalogueOf(strings.ToUpper(parent))
+		if !ok {
+			return &RuleError{Err: ErrUnsupportedLang, Msg: parent + " unsupport language"}
+		}
+		for key, message := range p {
+			catalogue[key] = message
+		}
+	}
+	for key, message := range messages {
+		if key != LANG_EXTENDS {
+			catalogue[key] = message
+		}
+	}
+	// 完整性检查
+	base, _ := catalogueOf(DEFAULT_LANG)
+	var missing []string
+	for key := range base {
+		if _, ok := catalogue[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return &RuleError{Err: ErrInvalidLang, Msg: name + " missing messages: " + strings.Join(missing, ", ")}
+	}
+	langs.Lock()
+	defer langs.Unlock()
+	i18n.Errors[lang] = catalogue
+	return nil
+}
+
+// LoadLang 读取语言包，format 为 FORMAT_JSON 或 FORMAT_YAML（yml），返回的错误信息可由 RegisterLang 注册
+// 语言包为一级键值对，值必须是字符串，YAML 仅支持 key: value 形式的单行键值对、注释及引号
+func LoadLang(r io.Reader, format string) (map[string]string, error) {
+	switch strings.ToLower(format) {
+	case FORMAT_JSON:
+		messages := map[string]string{}
+		if err := json.NewDecoder(r).Decode(&messages); err != nil {
+			return nil, &RuleError{Err: ErrInvalidLang, Msg: "invalid json: " + err.Error()}
+		}
+		return messages, nil
+	case FORMAT_YAML, "yml":
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return parseYAML(string(b))
+	}
+	return nil, &RuleError{Err: ErrInvalidLang, Msg: "unsupport format " + format}
+}
+
+// LoadLangFile 读取并注册语言包文件，语言名为文件名（不含扩展名），格式由扩展名决定，如 ./lang/en_gb.yaml
+func LoadLangFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	ext := filepath.Ext(path)
+	messages, err := LoadLang(f, strings.TrimPrefix(ext, "."))
+	if err != nil {
+		return err
+	}
+	return RegisterLang(strings.TrimSuffix(filepath.Base(path), ext), messages)
+}
+
+// parseYAML 解析一级键值对的 YAML，如 required: "can not be empty"
+func parseYAML(s string) (map[string]string, error) {
+	messages := map[string]string{}
+	for i, line := range strings.Split(strings.TrimPrefix(s, "\ufeff"), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == '#' || trimmed == "---" {
+			continue
+		}
+		invalid := func(msg string) (map[string]string, error) {
+			return nil, &RuleError{Err: ErrInvalidLang, Msg: fmt.Sprintf("invalid yaml: line %d: %s", i+1, msg)}
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			return invalid("nested value unsupported")
+		}
+		colon := strings.Index(line, ":")
+		if colon < 0 {
+			return invalid("':' not found")
+		}
+		key, err := yamlScalar(strings.TrimSpace(line[:colon]))
+		if err != nil || key == "" {
+			return invalid("invalid key")
+		}
+		value, err := yamlScalar(strings.TrimSpace(line[colon+1:]))
+		if err != nil {
+			return invalid(err.Error())
+		}
+		messages[key] = value
+	}
+	return messages, nil
+}
+
+// yamlScalar 解析 YAML 标量，支持双引号（转义同 Go）、单引号（连续两个单引号表示单引号）及无引号（# 开始的行尾注释）
+func yamlScalar(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	switch s[0] {
+	case '"':
+		end := closing(s, '"')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string")
+		}
+		if rest := strings.TrimSpace(s[end+1:]); rest != "" && rest[0] != '#' {
+			return "", fmt.Errorf("unexpected %q", rest)
+		}
+		return strconv.Unquote(s[:end+1])
+	case '\'':
+		end := closing(s, '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string")
+		}
+		if rest := strings.TrimSpace(s[end+1:]); rest != "" && rest[0] != '#' {
+			return "", fmt.Errorf("unexpected %q", rest)
+		}
+		return strings.Replace(s[1:end], "''", "'", -1), nil
+	case '[', '{', '|', '>', '&', '*', '!':
+		return "", fmt.Errorf("unsupported value %q", s)
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
+
+// closing 引号字符串的结束引号位置，双引号跳过转义字符，单引号跳过连续两个单引号
+func closing(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// AcceptLang 根据 HTTP 请求头 Accept-Language 选择已注册的语言（见 i18n.Errors、RegisterLang），如 "en-GB,en;q=0.9,zh;q=0.8"
+// 按 q 值从高到低（相同时按出现顺序）依次匹配，先匹配完整的语言标签（en-GB => EN_GB），再匹配主语言（en-GB => EN、EN_US 等）
+// 主语言匹配到多个语言时，优先选择与主语言同名的语言，否则按语言名排序选择第一个；q=0 及 * 忽略，均不匹配时返回空字符串
+func AcceptLang(header string) string {
+	type tag struct {
+		name string
+		q    float64
+	}
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToUpper(strings.Replace(strings.TrimSpace(fields[0]), "-", "_", -1))
+		if name == "" || name == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") || strings.HasPrefix(param, "Q=") {
+				f, err := strconv.ParseFloat(param[2:], 64)
+				if err != nil || f < 0 || f > 1 {
+					f = 0
+				}
+				q = f
+			}
+		}
+		if q > 0 {
+			tags = append(tags, tag{name, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	langs.RLock()
+	defer langs.RUnlock()
+	for _, t := range tags {
+		if _, ok := i18n.Errors[t.name]; ok {
+			return t.name
+		}
+		primary := strings.SplitN(t.name, "_", 2)[0]
+		if _, ok := i18n.Errors[primary]; ok {
+			return primary
+		}
+		var candidates []string
+		for lang := range i18n.Errors {
+			if strings.SplitN(lang, "_", 2)[0] == primary {
+				candidates = append(candidates, lang)
+			}
+		}
+		if len(candidates) != 0 {
+			sort.Strings(candidates)
+			return candidates[0]
+		}
+	}
+	return ""
+}
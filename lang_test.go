@@ -0,0 +1,263 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/goindow/validator/i18n"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+// completeOf 基于语言的完整错误信息，覆盖 messages
+func completeOf(lang string, messages map[string]string) map[string]string {
+	catalogue := map[string]string{}
+	for key, message := range i18n.Errors[lang] {
+		catalogue[key] = message
+	}
+	for key, message := range messages {
+		catalogue[key] = message
+	}
+	return catalogue
+}
+
+/***** RegisterLang() *****/
+
+// 注册语言包，语言名不区分大小写
+func Test_RegisterLang(t *testing.T) {
+	if err := RegisterLang("test_register", completeOf(i18n.EN_US, map[string]string{"required": "is required"})); err != nil {
+		fail(t, "should return nil, got "+err.Error())
+		return
+	}
+	e := New().Lang("TEST_REGISTER").Validate(Rules{"create": {{Attr: "username", Rule: "required"}}}, objEmpty, "create")
+	// toolbox.Dump(e) // [map[username:is required]]
+	if len(e) != 1 || e[0]["username"] != "is required" {
+		fail(t, "should print error(is required), got "+fmt.Sprint(e))
+	}
+}
+
+// 缺少错误信息的键
+func Test_RegisterLang_Missing(t *testing.T) {
+	err := RegisterLang("test_missing", map[string]string{"required": "is required"})
+	if !errors.Is(err, ErrInvalidLang) || !strings.Contains(err.Error(), "integerMax") {
+		fail(t, "should return error(missing messages), got "+fmt.Sprint(err))
+	}
+	if _, ok := catalogueOf("TEST_MISSING"); ok {
+		fail(t, "should not register language")
+	}
+}
+
+// 缺少的错误信息使用父语言的错误信息
+func Test_RegisterLang_Extends(t *testing.T) {
+	err := RegisterLang("test_extends", map[string]string{LANG_EXTENDS: "en_us", "required": "is required"})
+	if err != nil {
+		fail(t, "should return nil, got "+err.Error())
+		return
+	}
+	rules := Rules{"create": {{Attr: "username", Rule: "required"}, {Attr: "age", Rule: "int"}}}
+	e := New().Lang("test_extends").Validate(rules, M{"age": "x"}, "create")
+	// toolbox.Dump(e) // [map[username:is required] map[age:must be an integer]]
+	want := []E{{"username": "is required"}, {"age": i18n.Errors[i18n.EN_US]["integer"]}}
+	if !reflect.DeepEqual(e, want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	if _, ok := i18n.Errors["TEST_EXTENDS"][LANG_EXTENDS]; ok {
+		fail(t, "should not register extends as message")
+	}
+	// 父语言不存在
+	if err := RegisterLang("test_extends_undefined", map[string]string{LANG_EXTENDS: "fr"}); !errors.Is(err, ErrUnsupportedLang) {
+		fail(t, "should return error(unsupport language), got "+fmt.Sprint(err))
+	}
+}
+
+// 注册语言包的同时构造验证器、验证（go test -race）
+func Test_RegisterLang_Concurrent(t *testing.T) {
+	rules := Rules{"create": {{Attr: "username", Rule: "required"}}}
+	messages, required := completeOf(i18n.EN_US, nil), i18n.Errors[i18n.ZH_CN]["required"]
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			if err := RegisterLang("test_concurrent_"+strconv.Itoa(i), messages); err != nil {
+				fail(t, "should return nil, got "+err.Error())
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			if e := New().Validate(rules, objEmpty, "create"); len(e) != 1 || e[0]["username"] != required {
+				fail(t, "should print error of username, got "+fmt.Sprint(e))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+/***** LoadLang() *****/
+
+// JSON 语言包
+func Test_LoadLang_JSON(t *testing.T) {
+	messages, err := LoadLang(strings.NewReader(`{"extends": "en_us", "required": "{label} is required"}`), FORMAT_JSON)
+	want := map[string]string{"extends": "en_us", "required": "{label} is required"}
+	if err != nil || !reflect.DeepEqual(messages, want) {
+		fail(t, "should return "+fmt.Sprint(want)+", got "+fmt.Sprint(messages, err))
+	}
+	for _, s := range []string{`{"required": 1}`, `["required"]`, `{"required": "x"`} {
+		if _, err := LoadLang(strings.NewReader(s), "JSON"); !errors.Is(err, ErrInvalidLang) {
+			fail(t, s+" should return error(invalid json), got "+fmt.Sprint(err))
+		}
+	}
+}
+
+// YAML 语言包，一级键值对、注释、引号
+func Test_LoadLang_YAML(t *testing.T) {
+	s := strings.Join([]string{
+		"# en_gb",
+		"---",
+		"extends: en_us",
+		"required: can not be empty # comment",
+		`"stringLengthMax": "{label}'s maximum length is {max}"`,
+		`in: 'must be in {enum}, it''s #1'`,
+		`regex: "must match \"{value}\"\t"`,
+		"",
+		"empty:",
+	}, "\r\n")
+	messages, err := LoadLang(strings.NewReader(s), "yml")
+	// toolbox.Dump(messages)
+	want := map[string]string{
+		"extends":         "en_us",
+		"required":        "can not be empty",
+		"stringLengthMax": "{label}'s maximum length is {max}",
+		"in":              "must be in {enum}, it's #1",
+		"regex":           "must match \"{value}\"\t",
+		"empty":           "",
+	}
+	if err != nil || !reflect.DeepEqual(messages, want) {
+		fail(t, "should return "+fmt.Sprint(want)+", got "+fmt.Sprint(messages, err))
+	}
+	for _, s := range []string{"required", "  required: x", "required: {label} x", `required: "x`, `required: 'x' y`, "required:\n  - x"} {
+		if _, err := LoadLang(strings.NewReader(s), FORMAT_YAML); !errors.Is(err, ErrInvalidLang) {
+			fail(t, s+" should return error(invalid yaml), got "+fmt.Sprint(err))
+		}
+	}
+}
+
+// 不支持的格式
+func Test_LoadLang_Format(t *testing.T) {
+	if _, err := LoadLang(strings.NewReader(""), "toml"); !errors.Is(err, ErrInvalidLang) {
+		fail(t, "should return error(unsupport format), got "+fmt.Sprint(err))
+	}
+}
+
+/***** LoadLangFile() *****/
+
+// 读取并注册语言包文件，语言名为文件名
+func Test_LoadLangFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test_file.yaml")
+	if err := os.WriteFile(path, []byte("extends: zh_cn\nrequired: 必填\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadLangFile(path); err != nil {
+		fail(t, "should return nil, got "+err.Error())
+		return
+	}
+	e := New().Lang("test_file").Validate(Rules{"create": {{Attr: "username", Rule: "required"}}}, objEmpty, "create")
+	if len(e) != 1 || e[0]["username"] != "必填" {
+		fail(t, "should print error(必填), got "+fmt.Sprint(e))
+	}
+	if err := LoadLangFile(filepath.Join(dir, "undefined.json")); !errors.Is(err, os.ErrNotExist) {
+		fail(t, "should return error(not exist), got "+fmt.Sprint(err))
+	}
+}
+
+/***** WithLang() *****/
+
+// 单次验证的语言，不影响验证器的默认语言，可并发使用
+func Test_WithLang(t *testing.T) {
+	rules := Rules{"create": {{Attr: "username", Rule: "required"}, {Attr: "nickname", Rule: "string", Max: 3}}}
+	obj := M{"nickname": "hyb123"}
+	vLang := New().SetLabels(EN_US, map[string]string{"nickname": "Nickname"})
+	zh := []E{{"username": i18n.Errors[i18n.ZH_CN]["required"]}, {"nickname": generator(i18n.Errors[i18n.ZH_CN]["stringLengthMax"], "nickname", 3)}}
+	en := []E{{"username": i18n.Errors[i18n.EN_US]["required"]}, {"nickname": "Nickname's maximum length is 3"}}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lang, want := "zh_cn", zh
+			if i%2 == 0 {
+				lang, want = "en_us", en
+			}
+			if i%4 == 0 {
+				lang, want = "", zh
+			}
+			if e := vLang.Validate(rules, obj, "create", WithLang(lang)); !reflect.DeepEqual(e, want) {
+				fail(t, lang+" should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+			}
+		}(i)
+	}
+	wg.Wait()
+	if e := vLang.MustCompile(rules).Validate(obj, "create", WithLang(EN_US)); !reflect.DeepEqual(e, en) {
+		fail(t, "should print "+fmt.Sprint(en)+", got "+fmt.Sprint(e))
+	}
+	if vLang.lang != i18n.ZH_CN {
+		fail(t, "should not change default language, got "+vLang.lang)
+	}
+}
+
+// 不支持的语言
+func Test_WithLang_Undefined_Lang(t *testing.T) {
+	rules := Rules{"create": {{Attr: "username", Rule: "required"}}}
+	if _, err := v.ValidateE(rules, objEmpty, "create", WithLang("fr")); !errors.Is(err, ErrUnsupportedLang) {
+		fail(t, "should return error(unsupport language), got "+fmt.Sprint(err))
+	}
+	if err := v.Check(rules, objEmpty, "create", WithLang("fr")); !errors.Is(err, ErrUnsupportedLang) {
+		fail(t, "should return error(unsupport language), got "+fmt.Sprint(err))
+	}
+}
+
+/***** AcceptLang() *****/
+
+// q 值、地区回退
+func Test_AcceptLang(t *testing.T) {
+	cases := map[string]string{
+		"en-GB,en;q=0.9,zh;q=0.8":  i18n.EN_US,
+		"en-US":                    i18n.EN_US,
+		"en_us":                    i18n.EN_US,
+		"zh-TW":                    i18n.ZH_CN,
+		"fr-FR, en;q=0.5":          i18n.EN_US,
+		"en-us;q=0.5, zh-cn;q=0.8": i18n.ZH_CN,
+		"en;q=0, zh;q=0.1":         i18n.ZH_CN,
+		"en;q=abc, zh":             i18n.ZH_CN,
+		"EN;Q=0.2, fr;q=1, de":     i18n.EN_US,
+		"fr, de":                   "",
+		"*":                        "",
+		"":                         "",
+	}
+	for header, want := range cases {
+		if got := AcceptLang(header); got != want {
+			fail(t, header+" should be "+want+", got "+got)
+		}
+	}
+	// 完整的语言标签优先于主语言
+	if err := RegisterLang("en_gb", map[string]string{LANG_EXTENDS: "en_us"}); err != nil {
+		fail(t, "should return nil, got "+err.Error())
+	}
+	defer func() {
+		langs.Lock()
+		delete(i18n.Errors, "EN_GB")
+		langs.Unlock()
+	}()
+	if got := AcceptLang("en-GB"); got != "EN_GB" {
+		fail(t, "en-GB should be EN_GB, got "+got)
+	}
+	if got := AcceptLang("en-AU"); got != "EN_GB" {
+		fail(t, "en-AU should be EN_GB (sorted), got "+got)
+	}
+}
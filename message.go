@@ -0,0 +1,153 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 错误信息的命名占位符
+// {label} 属性的标签（见 SetLabels），{attr} 属性路径，{value} 属性的值，{other} 被比较的其他属性的标签（未设置标签时为属性路径）
+// 其他占位符为错误信息的参数（见 ValidationError.Params），如 {min}、{max}、{enum}
+const (
+	PLACEHOLDER_LABEL = "label"
+	PLACEHOLDER_ATTR  = "attr"
+	PLACEHOLDER_VALUE = "value"
+	PLACEHOLDER_OTHER = "other"
+)
+
+// SetMessages 设置语言的错误信息，覆盖该验证器的内置错误信息（见 i18n），不影响其他验证器，多次调用合并，不支持的语言将 panic
+// 键为错误信息的键（见 ValidationError.Key），自定义验证器为验证器名，覆盖自定义验证器返回的错误信息，Rule.Message 优先
+func (this *validator) SetMessages(lang string, messages map[string]string) *validator {
+	l := strings.ToUpper(lang)
+	if _, ok := catalogueOf(l); !ok {
+		panic(&RuleError{Err: ErrUnsupportedLang, Msg: lang + " unsupport language"})
+	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.messages == nil {
+		this.messages = make(map[string]map[string]string)
+	}
+	if this.messages[l] == nil {
+		this.messages[l] = make(map[string]string, len(messages))
+	}
+	for key, message := range messages {
+		this.messages[l][key] = message
+	}
+	return this
+}
+
+// render 生成错误信息，在属性路径确定后（嵌套验证、严格模式）统一生成，lang 为本次验证的语言，空字符串为默认语言
+// 依次使用 Rule.Message、SetMessages 设置的错误信息、自定义验证器返回的错误信息、该语言的内置错误信息，替换命名占位符（见 format）
+func (this *validator) render(errs Errors, lang string) {
+	if len(errs) == 0 {
+		return
+	}
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+	if lang == "" {
+		lang = this.lang
+	}
+	catalogue := this.default_errors
+	if lang != this.lang {
+		catalogue, _ = catalogueOf(lang)
+	}
+	labels, messages := this.labels[lang], this.messages[lang]
+	for _, e := range errs {
+		template, ok := e.template, e.template != ""
+		if !ok {
+			template, ok = messages[e.Key]
+		}
+		if !ok && e.fallback != "" {
+			template, ok = e.fallback, true
+		}
+		if !ok {
+			template, ok = catalogue[e.Key]
+		}
+		if !ok { // 错误信息不存在
+			e.Message = "unknow error"
+			continue
+		}
+		params := e.placeholders(this.labelOf(e, labels))
+		if len(e.others) != 0 {
+			params[PLACEHOLDER_OTHER] = othersLabel(e, labels)
+		}
+		e.Message = format(template, params)
+	}
+}
+
+// placeholders 错误信息的参数及 {label}、{attr}、{value}，无值时 {value} 为空字符串
+func (this *ValidationError) placeholders(label string) map[string]interface{} {
+	params := make(map[string]interface{}, len(this.Params)+3)
+	for name, value := range this.Params {
+		params[name] = value
+	}
+	params[PLACEHOLDER_LABEL] = label
+	params[PLACEHOLDER_ATTR] = this.Attr
+	params[PLACEHOLDER_VALUE] = ""
+	if this.Value != nil {
+		params[PLACEHOLDER_VALUE] = this.Value
+	}
+	return params
+}
+
+// format 替换模板中的命名占位符，如 "必须是介于 {min} 到 {max} 的整数"
+// 单次扫描替换，参数值原样插入，其中的 { } 不会被再次解析；{{、}} 转义为 {、}；params 中不存在的占位符原样保留
+func format(template string, params map[string]interface{}) string {
+	if !strings.ContainsAny(template, "{}") {
+		return template
+	}
+	var b strings.Builder
+	scan(template, func(literal string) {
+		b.WriteString(literal)
+	}, func(name string) {
+		if value, ok := params[name]; ok {
+			b.WriteString(fmt.Sprint(value))
+		} else {
+			b.WriteString("{" + name + "}")
+		}
+	})
+	return b.String()
+}
+
+// placeholders 模板中的命名占位符，按出现顺序排列
+func placeholders(template string) []string {
+	var names []string
+	scan(template, func(string) {}, func(name string) {
+		names = append(names, name)
+	})
+	return names
+}
+
+// scan 扫描模板，依次回调字面量及占位符名，{{、}} 作为字面量 {、}，未闭合的 { 作为字面量
+func scan(template string, literal func(string), placeholder func(string)) {
+	for template != "" {
+		i := strings.IndexAny(template, "{}")
+		if i < 0 {
+			literal(template)
+			return
+		}
+		if i > 0 {
+			literal(template[:i])
+		}
+		template = template[i:]
+		// 转义
+		if len(template) > 1 && template[1] == template[0] {
+			literal(template[:1])
+			template = template[2:]
+			continue
+		}
+		if template[0] == '}' {
+			literal("}")
+			template = template[1:]
+			continue
+		}
+		j := strings.IndexAny(template[1:], "{}")
+		if j < 0 || template[1+j] != '}' { // 未闭合
+			literal("{")
+			template = template[1:]
+			continue
+		}
+		placeholder(template[1 : 1+j])
+		template = template[2+j:]
+	}
+}
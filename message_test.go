@@ -0,0 +1,194 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"github.com/goindow/validator/i18n"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+/***** 命名占位符 *****/
+
+// 替换命名占位符，参数值不再解析，{{、}} 转义，未知占位符原样保留
+func Test_Format(t *testing.T) {
+	params := map[string]interface{}{"min": 1, "max": 10, "value": "{min}"}
+	cases := map[string]string{
+		"必须是介于 {min} 到 {max} 的整数": "必须是介于 1 到 10 的整数",
+		"{max} >= {min}":  "10 >= 1",
+		"{value} 无效":      "{min} 无效",
+		"{{min}} 为 {min}": "{min} 为 1",
+		"{unknown} {min}": "{unknown} 1",
+		"{min":            "{min",
+		"min}":            "min}",
+		"{ {min}":         "{ 1",
+		"没有占位符":           "没有占位符",
+	}
+	for template, want := range cases {
+		if got := format(template, params); got != want {
+			fail(t, template+" should be "+want+", got "+got)
+		}
+	}
+}
+
+// 内置错误信息只能使用已知的占位符，各语言同一错误信息的占位符相同
+func Test_Catalogue_Placeholders(t *testing.T) {
+	known := map[string]bool{PLACEHOLDER_LABEL: true, PLACEHOLDER_ATTR: true, PLACEHOLDER_VALUE: true, "min": true, "max": true, "enum": true, "other": true, "layout": true, "before": true, "after": true}
+	for _, lang := range []string{i18n.ZH_CN, i18n.EN_US} {
+		for key, message := range i18n.Errors[lang] {
+			if strings.Contains(message, "%") {
+				fail(t, lang+"."+key+" should not use positional placeholder, got "+message)
+			}
+			for _, name := range placeholders(message) {
+				if !known[name] {
+					fail(t, lang+"."+key+" has unknown placeholder {"+name+"}")
+				}
+			}
+			if lang == i18n.ZH_CN {
+				continue
+			}
+			zh, en := paramNames(i18n.Errors[i18n.ZH_CN][key]), paramNames(message)
+			if !reflect.DeepEqual(zh, en) {
+				fail(t, lang+"."+key+" should use placeholders "+fmt.Sprint(zh)+", got "+fmt.Sprint(en))
+			}
+		}
+	}
+}
+
+// paramNames 错误信息的参数占位符（不含 {label}、{attr}、{value}），排序去重
+func paramNames(message string) []string {
+	set := map[string]bool{}
+	for _, name := range placeholders(message) {
+		if name != PLACEHOLDER_LABEL && name != PLACEHOLDER_ATTR && name != PLACEHOLDER_VALUE {
+			set[name] = true
+		}
+	}
+	names := []string{}
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 自定义错误信息使用命名占位符
+func Test_Message_Placeholders(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "age", Rule: "int", Min: 18, Max: 60, Message: "{attr} 的值 {value} 不在 {min}~{max} 之间"},
+			{Attr: "gender", Rule: "in", Enum: []string{"0", "1"}, Message: "{label} 只能是 {enum}", Label: "性别"},
+			{Attr: "rpassword", Rule: "same", Other: "password", Message: "{label} 与 {other} 不一致"},
+			{Attr: "username", Rule: "required", Message: "{{username}} 不能为空，{value}"},
+		},
+	}
+	e := v.Validate(rules, M{"age": 17, "gender": "2", "password": "a", "rpassword": "b"}, "create")
+	// toolbox.Dump(e)
+	want := []E{
+		{"age": "age 的值 17 不在 18~60 之间"},
+		{"gender": "性别 只能是 [0、1]"},
+		{"rpassword": "rpassword 与 password 不一致"},
+		{"username": "{username} 不能为空，"},
+	}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+}
+
+// 调整占位符顺序
+func Test_Message_Reorder(t *testing.T) {
+	vReorder := New()
+	vReorder.AddValidator("between", func(attr string, rule Rule, obj M) E {
+		return vReorder.generator("integerRange", attr, Rule{Message: "{max} >= {attr} >= {min}"}, "min", 1, "max", 9)
+	})
+	e := vReorder.Validate(Rules{"create": {{Attr: "n", Rule: "between"}}}, M{"n": 10}, "create")
+	if len(e) != 1 || e[0]["n"] != "9 >= n >= 1" {
+		fail(t, "should print error(9 >= n >= 1), got "+fmt.Sprint(e))
+	}
+}
+
+/***** SetMessages() *****/
+
+// 覆盖内置错误信息，不影响其他验证器，Rule.Message 优先
+func Test_SetMessages(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "age", Rule: "int", Symbol: 1, Min: 18},
+			{Attr: "weight", Rule: "int", Symbol: 1, Min: 18, Message: "{label} 太轻"},
+			{Attr: "username", Rule: "required"},
+		},
+	}
+	obj := M{"age": 17, "weight": 17}
+	vMessages := New().SetMessages(ZH_CN, map[string]string{"integerPositiveMin": "{label}至少为 {min}"}).SetMessages("zh_cn", map[string]string{"required": "{label}必填"})
+	vMessages.SetMessages(EN_US, map[string]string{"required": "{label} is required"})
+	e := vMessages.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[age:age至少为 18] map[weight:weight 太轻] map[username:username必填]]
+	want := []E{{"age": "age至少为 18"}, {"weight": "weight 太轻"}, {"username": "username必填"}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// 其他语言
+	e = vMessages.Validate(rules, obj, "create", WithLang(EN_US))
+	want = []E{{"age": generator(i18n.Errors[i18n.EN_US]["integerPositiveMin"], "age", 18)}, {"weight": "weight 太轻"}, {"username": "username is required"}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// 其他验证器
+	if e := v.Validate(rules, obj, "create"); e[0]["age"] != generator(v.default_errors["integerPositiveMin"], "age", 18) {
+		fail(t, "should not affect other validators, got "+fmt.Sprint(e))
+	}
+}
+
+// 自定义验证器的错误信息
+func Test_SetMessages_Custom(t *testing.T) {
+	vMessages := New().SetMessages(ZH_CN, map[string]string{"even": "{label}必须是偶数，当前为 {value}", "positive": "{label}必须是正数"})
+	vMessages.AddValidator("even", func(attr string, rule Rule, obj M) E {
+		if n, _ := obj[attr].(int); n%2 != 0 {
+			return E{attr: "must be even"}
+		}
+		return nil
+	})
+	vMessages.AddValidator("positive", func(attr string, rule Rule, obj M) E {
+		if n, _ := obj[attr].(int); n <= 0 {
+			return E{attr: ""}
+		}
+		return nil
+	})
+	vMessages.AddValidator("odd", func(attr string, rule Rule, obj M) E {
+		if n, _ := obj[attr].(int); n%2 == 0 {
+			return E{attr: ""}
+		}
+		return nil
+	})
+	rules := Rules{
+		"create": {
+			{Attr: "a", Rule: "even"},
+			{Attr: "b", Rule: "even", Message: "b 不是偶数"},
+			{Attr: "c", Rule: "positive"},
+			{Attr: "d", Rule: "odd"},
+		},
+	}
+	e := vMessages.Validate(rules, M{"a": 1, "b": 1, "c": -1, "d": 2}, "create")
+	// toolbox.Dump(e)
+	want := []E{{"a": "a必须是偶数，当前为 1"}, {"b": "b 不是偶数"}, {"c": "c必须是正数"}, {"d": "unknow error"}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// 未覆盖的语言使用自定义验证器返回的错误信息
+	if e := vMessages.Validate(rules, M{"a": 1, "b": 2, "c": 1, "d": 1}, "create", WithLang(EN_US)); len(e) != 1 || e[0]["a"] != "must be even" {
+		fail(t, "should print error(must be even), got "+fmt.Sprint(e))
+	}
+}
+
+// 不支持的语言
+func Test_SetMessages_Undefined_Lang(t *testing.T) {
+	defer func() {
+		p := recover()
+		if err, ok := p.(error); !ok || !errors.Is(err, ErrUnsupportedLang) {
+			fail(t, "should panic(unsupport language), got "+fmt.Sprint(p))
+		}
+	}()
+	New().SetMessages("fr", map[string]string{"required": "obligatoire"})
+}
@@ -0,0 +1,278 @@
+package validator
+
+import (
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// IP 地址分类，用于 Rule.Classes
+const (
+	IP_PUBLIC      = "public"      // 公网地址
+	IP_PRIVATE     = "private"     // 内网地址，10.0.0.0/8、172.16.0.0/12、192.168.0.0/16、fc00::/7
+	IP_LOOPBACK    = "loopback"    // 回环地址，127.0.0.0/8、::1
+	IP_MULTICAST   = "multicast"   // 组播地址，224.0.0.0/4、ff00::/8（链路本地组播除外）
+	IP_LINK_LOCAL  = "link_local"  // 链路本地地址，169.254.0.0/16、fe80::/10、224.0.0.0/24、ff02::/16
+	IP_UNSPECIFIED = "unspecified" // 未指定地址，0.0.0.0、::
+)
+
+// ipClasses 有效的 IP 地址分类
+var ipClasses = []string{IP_PUBLIC, IP_PRIVATE, IP_LOOPBACK, IP_MULTICAST, IP_LINK_LOCAL, IP_UNSPECIFIED}
+
+// ipv4Validator ipv4（不包括 IPv4-mapped 地址，如 ::ffff:127.0.0.1），同 ipValidator
+func (this *validator) ipv4Validator(attr string, rule Rule, obj M) Errors {
+	return this.ipAddress("ipv4", attr, rule, obj)
+}
+
+// ipv6Validator ipv6（不包括 ipv4 及 IPv4-mapped 地址，如 ::ffff:127.0.0.1、::ffff:7f00:1），同 ipValidator
+func (this *validator) ipv6Validator(attr string, rule Rule, obj M) Errors {
+	return this.ipAddress("ipv6", attr, rule, obj)
+}
+
+// ipAddress ip 地址验证，name 为 ip、ipv4、ipv6
+func (this *validator) ipAddress(name string, attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseIP)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 字符串检测
+	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
+		return this.failure("string", attr, rule)
+	}
+	// ip 检测
+	s := obj[attr].(string)
+	ip := net.ParseIP(s)
+	if ip == nil || name == "ipv4" && (ip.To4() == nil || strings.Contains(s, ":")) || name == "ipv6" && ip.To4() != nil {
+		return this.failure(name, attr, rule)
+	}
+	// 网段检测
+	if len(p.networks) > 0 && !inNetworks(ip, p.networks) {
+		return this.failure("ipNetwork", attr, rule, "enum", "["+strings.Join(rule.Networks, "、")+"]")
+	}
+	// 分类检测
+	if len(rule.Classes) > 0 && !contains(rule.Classes, ipClass(ip)) {
+		return this.failure("ipClass", attr, rule, "enum", "["+strings.Join(rule.Classes, "、")+"]")
+	}
+	return nil
+}
+
+// cidrValidator CIDR 表示的网段，如 192.168.0.0/16、2001:db8::/32
+// Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+func (this *validator) cidrValidator(attr string, rule Rule, obj M) Errors {
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 字符串检测
+	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
+		return this.failure("string", attr, rule)
+	}
+	// cidr 检测
+	if _, _, err := net.ParseCIDR(obj[attr].(string)); err != nil {
+		return this.failure("cidr", attr, rule)
+	}
+	return nil
+}
+
+// macValidator MAC 地址，支持 net.ParseMAC 的格式，如 00:00:5e:00:53:01、00-00-5e-00-53-01、0000.5e00.5301
+// Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+func (this *validator) macValidator(attr string, rule Rule, obj M) Errors {
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 字符串检测
+	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
+		return this.failure("string", attr, rule)
+	}
+	// mac 检测
+	if _, err := net.ParseMAC(obj[attr].(string)); err != nil {
+		return this.failure("mac", attr, rule)
+	}
+	return nil
+}
+
+// hostnameValidator 主机名（RFC 1123），如 example.com、localhost，允许以 . 结尾
+// Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+func (this *validator) hostnameValidator(attr string, rule Rule, obj M) Errors {
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 字符串检测
+	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
+		return this.failure("string", attr, rule)
+	}
+	// 主机名检测
+	if !isHostname(obj[attr].(string)) {
+		return this.failure("hostname", attr, rule)
+	}
+	return nil
+}
+
+// portValidator 端口，1-65535，被验证字段支持类型 int*、整数值的 float*、整数字符串
+// Rule.Required    bool        可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Ports       []string    可选    允许的端口或端口范围，如 443、8000-8999
+func (this *validator) portValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parsePort)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 端口检测
+	port, ok := toPort(obj[attr])
+	if !ok {
+		return this.failure("port", attr, rule)
+	}
+	if len(p.ports) > 0 && !inPorts(port, p.ports) {
+		return this.failure("portRange", attr, rule, "enum", "["+strings.Join(rule.Ports, "、")+"]")
+	}
+	return nil
+}
+
+// hostPortValidator 主机与端口，如 example.com:80、127.0.0.1:8080、[::1]:443，主机为 ip 或主机名（RFC 1123）
+// Rule.Required    bool        可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Ports       []string    可选    允许的端口或端口范围，如 443、8000-8999
+func (this *validator) hostPortValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parsePort)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 字符串检测
+	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
+		return this.failure("string", attr, rule)
+	}
+	// host:port 检测
+	host, s, err := net.SplitHostPort(obj[attr].(string))
+	if err != nil || net.ParseIP(host) == nil && !isHostname(host) {
+		return this.failure("hostPort", attr, rule)
+	}
+	port, ok := toPort(s)
+	if !ok {
+		return this.failure("hostPort", attr, rule)
+	}
+	if len(p.ports) > 0 && !inPorts(port, p.ports) {
+		return this.failure("portRange", attr, rule, "enum", "["+strings.Join(rule.Ports, "、")+"]")
+	}
+	return nil
+}
+
+// isHostname 是否为 RFC 1123 主机名，总长度不超过 253（不包括结尾的 .）
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if len(s) == 0 || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !isHostnameLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHostnameLabel 是否为主机名的标签，字母、数字、连字符，不能以连字符开头或结尾，长度 1-63
+func isHostnameLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// toPort 转换为端口，支持类型 int*、整数值的 float*（如 JSON 解码的数字）、整数字符串，范围 1-65535
+func toPort(value interface{}) (int, bool) {
+	var f float64
+	switch v := value.(type) {
+	case string:
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, false
+		}
+		f = float64(i)
+	case int, int8, int16, int32, int64, float32, float64:
+		f, _ = toNumber(v)
+	default:
+		return 0, false
+	}
+	if f != float64(int(f)) || f < 1 || f > 65535 {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// ipClass IP 地址的分类（见 IP_PUBLIC 等）
+func ipClass(ip net.IP) string {
+	switch {
+	case ip.IsUnspecified():
+		return IP_UNSPECIFIED
+	case ip.IsLoopback():
+		return IP_LOOPBACK
+	case ip.IsLinkLocalUnicast(), ip.IsLinkLocalMulticast():
+		return IP_LINK_LOCAL
+	case ip.IsMulticast():
+		return IP_MULTICAST
+	case ip.IsPrivate():
+		return IP_PRIVATE
+	}
+	return IP_PUBLIC
+}
+
+// inNetworks IP 是否属于网段之一
+func inNetworks(ip net.IP, networks []*net.IPNet) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIP ipValidator、ipv4Validator、ipv6Validator 规则参数，Rule.Networks 必须是有效的 CIDR，Rule.Classes 只能是 IP_PUBLIC 等分类
+func parseIP(rule Rule) *params {
+	p := &params{}
+	for _, s := range rule.Networks {
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Networks' should be CIDR like 192.168.0.0/16, got '"+s+"'"))
+		}
+		p.networks = append(p.networks, network)
+	}
+	for _, class := range rule.Classes {
+		if !contains(ipClasses, class) {
+			panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Classes' should be one of "+strings.Join(ipClasses, ", ")+", got '"+class+"'"))
+		}
+	}
+	return p
+}
+
+// parsePort portValidator、hostPortValidator 规则参数，Rule.Ports 必须是有效的端口或端口范围
+func parsePort(rule Rule) *params {
+	return &params{ports: parsePorts(rule)}
+}
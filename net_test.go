@@ -0,0 +1,247 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+/***** ipv4Validator、ipv6Validator *****/
+
+// 仅 ipv4、仅 ipv6
+func Test_Rule_IpVersionValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "v4", Rule: "ipv4"}, {Attr: "v6", Rule: "ipv6"}}}
+	if e := v.Validate(rules, M{"v4": "192.168.1.1", "v6": "2001:db8::1"}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	e := v.Validate(rules, M{"v4": "2001:db8::1", "v6": "::ffff:192.168.1.1"}, "create")
+	// toolbox.Dump(e) // [map[v4:无效的 ipv4] map[v6:无效的 ipv6]]
+	want := []E{{"v4": generator(v.default_errors["ipv4"], "v4")}, {"v6": generator(v.default_errors["ipv6"], "v6")}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// IPv4-mapped 地址既不是 ipv4 也不是 ipv6
+	for _, value := range []string{"::ffff:127.0.0.1", "::ffff:7f00:1"} {
+		if e := v.Validate(rules, M{"v4": value, "v6": value}, "create"); fmt.Sprint(e) != fmt.Sprint(want) {
+			fail(t, value+" should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+		}
+	}
+	if e := v.Validate(rules, M{"v4": 1, "v6": "192.168.1.1"}, "create"); len(e) != 2 || e[0]["v4"] != v.default_errors["string"] {
+		fail(t, "should print errors of v4 and v6, got "+fmt.Sprint(e))
+	}
+}
+
+// 网段
+func Test_Rule_IpValidator_Networks(t *testing.T) {
+	rules := Rules{"create": {{Attr: "ip", Rule: "ip", Networks: []string{"10.0.0.0/8", "2001:db8::/32"}}}}
+	for value, ok := range map[string]bool{"10.1.2.3": true, "2001:db8::1": true, "11.0.0.1": false, "2001:db9::1": false} {
+		if e := v.Validate(rules, M{"ip": value}, "create"); (len(e) == 0) != ok {
+			fail(t, fmt.Sprintf("%s should be valid(%v), got %v", value, ok, e))
+		}
+	}
+	e := New().Lang(EN_US).Validate(rules, M{"ip": "11.0.0.1"}, "create")
+	// toolbox.Dump(e) // [map[ip:must be in one of the networks [10.0.0.0/8、2001:db8::/32]]]
+	if want := "must be in one of the networks [10.0.0.0/8、2001:db8::/32]"; len(e) != 1 || e[0]["ip"] != want {
+		fail(t, "should print error("+want+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 分类
+func Test_Rule_IpValidator_Classes(t *testing.T) {
+	cases := map[string]string{
+		"8.8.8.8":         IP_PUBLIC,
+		"2001:4860::8888": IP_PUBLIC,
+		"10.0.0.1":        IP_PRIVATE,
+		"172.31.255.255":  IP_PRIVATE,
+		"192.168.0.1":     IP_PRIVATE,
+		"fd00::1":         IP_PRIVATE,
+		"127.0.0.1":       IP_LOOPBACK,
+		"::1":             IP_LOOPBACK,
+		"239.1.1.1":       IP_MULTICAST,
+		"ff0e::1":         IP_MULTICAST,
+		"169.254.169.254": IP_LINK_LOCAL,
+		"fe80::1":         IP_LINK_LOCAL,
+		"224.0.0.1":       IP_LINK_LOCAL,
+		"0.0.0.0":         IP_UNSPECIFIED,
+		"::":              IP_UNSPECIFIED,
+	}
+	for value, class := range cases {
+		for _, c := range ipClasses {
+			rules := Rules{"create": {{Attr: "ip", Rule: "ip", Classes: []string{c}}}}
+			if e := v.Validate(rules, M{"ip": value}, "create"); (len(e) == 0) != (c == class) {
+				fail(t, fmt.Sprintf("%s should be %s, got %v with class %s", value, class, e, c))
+			}
+		}
+	}
+	rules := Rules{"create": {{Attr: "ip", Rule: "ipv4", Classes: []string{IP_PRIVATE, IP_LOOPBACK}}}}
+	e := v.Validate(rules, M{"ip": "8.8.8.8"}, "create")
+	// toolbox.Dump(e) // [map[ip:地址类型只能是 [private、loopback] 中的一个]]
+	if message := generator(v.default_errors["ipClass"], "ip", "[private、loopback]"); len(e) != 1 || e[0]["ip"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+/***** cidrValidator *****/
+
+func Test_Rule_CidrValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "subnet", Rule: "cidr"}}}
+	for value, ok := range map[string]bool{"192.168.0.0/16": true, "10.1.2.3/32": true, "2001:db8::/32": true, "192.168.0.0": false, "192.168.0.0/33": false, "a/8": false} {
+		if e := v.Validate(rules, M{"subnet": value}, "create"); (len(e) == 0) != ok {
+			fail(t, fmt.Sprintf("%s should be valid(%v), got %v", value, ok, e))
+		}
+	}
+	e := v.Validate(rules, M{"subnet": "10.0.0.0"}, "create")
+	// toolbox.Dump(e) // [map[subnet:无效的 CIDR]]
+	if message := generator(v.default_errors["cidr"], "subnet"); len(e) != 1 || e[0]["subnet"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+/***** macValidator *****/
+
+func Test_Rule_MacValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "mac", Rule: "mac"}}}
+	for value, ok := range map[string]bool{"00:00:5e:00:53:01": true, "00-00-5E-00-53-01": true, "0000.5e00.5301": true, "00:00:5e:00:53": false, "00:00:5e:00:53:zz": false} {
+		if e := v.Validate(rules, M{"mac": value}, "create"); (len(e) == 0) != ok {
+			fail(t, fmt.Sprintf("%s should be valid(%v), got %v", value, ok, e))
+		}
+	}
+	e := New().Lang(EN_US).Validate(rules, M{"mac": "00:00"}, "create")
+	// toolbox.Dump(e) // [map[mac:must be a valid MAC address]]
+	if want := "must be a valid MAC address"; len(e) != 1 || e[0]["mac"] != want {
+		fail(t, "should print error("+want+"), got "+fmt.Sprint(e))
+	}
+}
+
+/***** hostnameValidator *****/
+
+func Test_Rule_HostnameValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "host", Rule: "hostname"}}}
+	cases := map[string]bool{
+		"localhost":                      true,
+		"example.com":                    true,
+		"Example.COM.":                   true,
+		"3com.com":                       true,
+		"a-b.c-d.e":                      true,
+		strings.Repeat("a", 63) + ".com": true,
+		strings.Repeat("a", 64) + ".com": false,
+		strings.Repeat("a.", 127) + "a":  false,
+		"-example.com":                   false,
+		"example-.com":                   false,
+		"exa_mple.com":                   false,
+		"example..com":                   false,
+		".example.com":                   false,
+		"例子.com":                         false,
+		"":                               false,
+	}
+	for value, ok := range cases {
+		if e := v.Validate(rules, M{"host": value}, "create"); (len(e) == 0) != ok {
+			fail(t, fmt.Sprintf("%s should be valid(%v), got %v", value, ok, e))
+		}
+	}
+	e := v.Validate(rules, M{"host": "a_b"}, "create")
+	// toolbox.Dump(e) // [map[host:无效的主机名]]
+	if message := generator(v.default_errors["hostname"], "host"); len(e) != 1 || e[0]["host"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+/***** portValidator、hostPortValidator *****/
+
+func Test_Rule_PortValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "port", Rule: "port"}}}
+	for _, value := range []interface{}{1, "80", int64(65535), float64(8080), int8(22)} {
+		if e := v.Validate(rules, M{"port": value}, "create"); len(e) != 0 {
+			fail(t, fmt.Sprintf("%#v should be valid, got %v", value, e))
+		}
+	}
+	for _, value := range []interface{}{0, "0", 65536, "-1", "80a", 80.5, true, "８０"} {
+		e := v.Validate(rules, M{"port": value}, "create")
+		// toolbox.Dump(e) // [map[port:无效的端口]]
+		if message := generator(v.default_errors["port"], "port"); len(e) != 1 || e[0]["port"] != message {
+			fail(t, fmt.Sprintf("%#v should print error(%s), got %v", value, message, e))
+		}
+	}
+	rules = Rules{"create": {{Attr: "port", Rule: "port", Ports: []string{"80", "1024-65535"}}}}
+	e := v.Validate(rules, M{"port": 443}, "create")
+	// toolbox.Dump(e) // [map[port:端口必须在 [80、1024-65535] 范围内]]
+	if message := generator(v.default_errors["portRange"], "port", "[80、1024-65535]"); len(e) != 1 || e[0]["port"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+func Test_Rule_HostPortValidator(t *testing.T) {
+	rules := Rules{"create": {{Attr: "addr", Rule: "host_port"}}}
+	cases := map[string]bool{
+		"example.com:80":   true,
+		"127.0.0.1:8080":   true,
+		"[::1]:443":        true,
+		"localhost:65535":  true,
+		"example.com":      false,
+		"::1:443":          false,
+		":80":              false,
+		"example.com:0":    false,
+		"example.com:http": false,
+		"exa_mple.com:80":  false,
+	}
+	for value, ok := range cases {
+		if e := v.Validate(rules, M{"addr": value}, "create"); (len(e) == 0) != ok {
+			fail(t, fmt.Sprintf("%s should be valid(%v), got %v", value, ok, e))
+		}
+	}
+	e := v.Validate(rules, M{"addr": "example.com"}, "create")
+	// toolbox.Dump(e) // [map[addr:必须是有效的 主机:端口]]
+	if message := generator(v.default_errors["hostPort"], "addr"); len(e) != 1 || e[0]["addr"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+	rules = Rules{"create": {{Attr: "addr", Rule: "host_port", Ports: []string{"443"}}}}
+	if e := v.Validate(rules, M{"addr": "example.com:80"}, "create"); len(e) != 1 || e[0]["addr"] != generator(v.default_errors["portRange"], "addr", "[443]") {
+		fail(t, "should print error of portRange, got "+fmt.Sprint(e))
+	}
+}
+
+// 无值
+func Test_Rule_NetValidator_Required(t *testing.T) {
+	rules := Rules{"create": {}}
+	for _, rule := range []string{"ipv4", "ipv6", "cidr", "mac", "hostname", "port", "host_port"} {
+		rules["create"] = append(rules["create"], Rule{Attr: rule, Rule: rule}, Rule{Attr: rule + "_required", Rule: rule, Required: true})
+	}
+	e := v.Validate(rules, objEmpty, "create")
+	// toolbox.Dump(e)
+	if len(e) != 7 {
+		fail(t, "should print 7 errors, got "+fmt.Sprint(e))
+	}
+	for _, item := range e {
+		for attr, message := range item {
+			if !strings.HasSuffix(attr, "_required") || message != v.default_errors["required"] {
+				fail(t, "should print error of required, got "+fmt.Sprint(item))
+			}
+		}
+	}
+}
+
+// 规则定义错误、结构体标签
+func Test_Rule_NetValidator_RuleErr(t *testing.T) {
+	for _, rule := range []Rule{
+		{Attr: "attr", Rule: "ip", Networks: []string{"10.0.0.0"}},
+		{Attr: "attr", Rule: "ipv4", Classes: []string{"reserved"}},
+		{Attr: "attr", Rule: "port", Ports: []string{"0-70000"}},
+		{Attr: "attr", Rule: "host_port", Ports: []string{"a"}},
+	} {
+		if _, err := v.Compile(Rules{"create": {rule}}); !errors.Is(err, ErrInvalidRuleParam) {
+			fail(t, fmt.Sprintf("%+v should return error(invalid rule param), got %v", rule, err))
+		}
+	}
+	type server struct {
+		IP string `json:"ip" validate:"create:ip,networks=10.0.0.0/8 192.168.0.0/16,classes=private"`
+	}
+	rules := MustStructRules(&server{})
+	if r := rules["create"][0]; len(r.Networks) != 2 || len(r.Classes) != 1 {
+		fail(t, "should parse params, got "+fmt.Sprintf("%+v", r))
+	}
+	if e := v.ValidateStruct(rules, &server{IP: "172.16.0.1"}, "create"); len(e) != 1 {
+		fail(t, "should print error of ip, got "+fmt.Sprint(e))
+	}
+}
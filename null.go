@@ -0,0 +1,66 @@
+package validator
+
+// Nullability null 处理方式，区分属性不存在与属性存在且值为 nil（如 JSON 的 null）
+type Nullability int
+
+const (
+	// nil 作为普通的值，由验证器验证（如 stringValidator 报 string 错误）
+	NULL_DEFAULT Nullability = iota
+	// 允许为 null，值为 nil 时跳过本条规则（包括 requiredValidator 及 Rule.Required）
+	NULL_ALLOW
+	// 禁止为 null，值为 nil 时报 notNull 错误
+	NULL_FORBID
+	// 值为 nil 时视为属性不存在（见 Emptiness），作用于内置验证器
+	NULL_ABSENT
+)
+
+// nullability 处理方式名，用于结构体标签，如 `validate:"update:string,nullable=allow"`
+var nullability = map[string]Nullability{
+	"allow":  NULL_ALLOW,
+	"forbid": NULL_FORBID,
+	"absent": NULL_ABSENT,
+}
+
+// valid 处理方式是否有效
+func (this Nullability) valid() bool {
+	return this >= NULL_DEFAULT && this <= NULL_ABSENT
+}
+
+// null 按 Rule.Nullable 处理值为 nil 的属性，skip 为 true 时跳过本条规则，not_null 不受 NULL_ALLOW 影响
+func (this *validator) null(c compiledRule, attr string, obj M) (failed Errors, skip bool) {
+	if value, ok := obj[attr]; !ok || value != nil {
+		return nil, false
+	}
+	switch c.rule.Nullable {
+	case NULL_ALLOW:
+		return nil, c.rule.Rule != "not_null"
+	case NULL_FORBID:
+		return this.failure("notNull", attr, c.rule), true
+	}
+	return nil, false
+}
+
+// nullableValidator 允许为 null，属性存在且值为 nil 时，同一场景中该属性的后续规则不再验证（见 validate）
+// Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段不存在，验证失败，报 reqired 错误
+func (this *validator) nullableValidator(attr string, rule Rule, obj M) Errors {
+	if _, ok := obj[attr]; !ok && rule.Required {
+		return this.failure("required", attr, rule)
+	}
+	return nil
+}
+
+// notNullValidator 禁止为 null，属性存在且值为 nil 时报 notNull 错误
+// Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段不存在，验证失败，报 reqired 错误
+func (this *validator) notNullValidator(attr string, rule Rule, obj M) Errors {
+	value, ok := obj[attr]
+	if !ok {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	if value == nil {
+		return this.failure("notNull", attr, rule)
+	}
+	return nil
+}
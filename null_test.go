@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+/***** Rule.Nullable *****/
+
+// 区分属性不存在与值为 null
+func Test_Nullable(t *testing.T) {
+	rules := Rules{
+		"update": {
+			{Attr: "nickname", Rule: "string", Max: 3, Nullable: NULL_ALLOW},
+			{Attr: "avatar", Rule: "string", Required: true, Nullable: NULL_ALLOW},
+			{Attr: "email", Rule: "email", Nullable: NULL_FORBID},
+			{Attr: "age", Rule: "int", Nullable: NULL_ABSENT},
+			{Attr: "weight", Rule: "number", Required: true, Nullable: NULL_ABSENT},
+			{Attr: "height", Rule: "number"},
+		},
+	}
+	obj := M{"nickname": nil, "avatar": nil, "email": nil, "age": nil, "weight": nil, "height": nil}
+	e := v.Validate(rules, obj, "update")
+	// toolbox.Dump(e) // [map[email:不能为 null] map[weight:不能为空] map[height:必须是数字]]
+	want := []E{{"email": v.default_errors["notNull"]}, {"weight": v.default_errors["required"]}, {"height": v.default_errors["number"]}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// 属性不存在
+	e = v.Validate(rules, objEmpty, "update")
+	want = []E{{"avatar": v.default_errors["required"]}, {"weight": v.default_errors["required"]}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// 有值
+	e = v.Validate(rules, M{"nickname": "hyb123", "avatar": "a.png", "email": "x", "age": 1, "weight": 1}, "update")
+	if len(e) != 2 || e[0]["nickname"] == "" || e[1]["email"] == "" {
+		fail(t, "should print errors of nickname and email, got "+fmt.Sprint(e))
+	}
+}
+
+// requiredValidator 允许为 null
+func Test_Nullable_Required(t *testing.T) {
+	rules := Rules{"update": {{Attr: "nickname", Rule: "required", Nullable: NULL_ALLOW, Empty: EMPTY_NIL}}}
+	if e := v.Validate(rules, M{"nickname": nil}, "update"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if e := v.Validate(rules, objEmpty, "update"); len(e) != 1 {
+		fail(t, "should print error of nickname, got "+fmt.Sprint(e))
+	}
+}
+
+/***** nullableValidator、notNullValidator *****/
+
+// nullable 之后的规则在值为 null 时跳过
+func Test_Rule_NullableValidator(t *testing.T) {
+	rules := Rules{
+		"update": {
+			{Attr: "nickname", Rule: "string"},
+			{Attr: []string{"nickname", "avatar"}, Rule: "nullable"},
+			{Attr: []string{"nickname", "avatar"}, Rule: "string", Min: 3},
+			{Attr: "address.city", Rule: "nullable", Required: true},
+			{Attr: "address.city", Rule: "string"},
+		},
+	}
+	e := v.Validate(rules, M{"nickname": nil, "avatar": "a", "address": M{"city": nil}}, "update")
+	// toolbox.Dump(e)
+	want := []E{{"nickname": v.default_errors["string"]}, {"avatar": generator(v.default_errors["stringLengthMin"], "avatar", 3)}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	e = v.Validate(rules, M{"address": M{}}, "update")
+	if len(e) != 1 || e[0]["address.city"] != v.default_errors["required"] {
+		fail(t, "should print error of address.city, got "+fmt.Sprint(e))
+	}
+}
+
+// 禁止为 null，错误信息与 required 不同
+func Test_Rule_NotNullValidator(t *testing.T) {
+	rules := Rules{
+		"update": {
+			{Attr: []string{"nickname", "avatar"}, Rule: "not_null"},
+			{Attr: "email", Rule: "not_null", Required: true, Nullable: NULL_ALLOW},
+		},
+	}
+	e := New().Lang(EN_US).Validate(rules, M{"nickname": nil, "avatar": "", "email": nil}, "update")
+	// toolbox.Dump(e) // [map[nickname:can not be null] map[email:can not be null]]
+	want := []E{{"nickname": "can not be null"}, {"email": "can not be null"}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	if e := v.Validate(rules, objEmpty, "update"); len(e) != 1 || e[0]["email"] != v.default_errors["required"] {
+		fail(t, "should print error of email, got "+fmt.Sprint(e))
+	}
+}
+
+// 无效的处理方式、结构体标签
+func Test_Nullable_RuleErr(t *testing.T) {
+	if _, err := v.ValidateE(Rules{"update": {{Attr: "attr", Rule: "string", Nullable: -1}}}, objEmpty, "update"); !errors.Is(err, ErrInvalidRuleParam) {
+		fail(t, "should return error(invalid rule param), got "+fmt.Sprint(err))
+	}
+	type user struct {
+		Nickname *string `json:"nickname" validate:"update:not_null"`
+		Avatar   *string `json:"avatar" validate:"update:string,nullable=allow"`
+	}
+	rules, err := StructRules(&user{})
+	if err != nil || rules["update"][1].Nullable != NULL_ALLOW {
+		fail(t, "should parse nullable, got "+fmt.Sprint(rules, err))
+	}
+	if _, err := StructRules(&struct {
+		Name string `validate:"update:string,nullable=yes"`
+	}{}); !errors.Is(err, ErrInvalidRule) {
+		fail(t, "should return error(invalid tag), got "+fmt.Sprint(err))
+	}
+}
@@ -0,0 +1,76 @@
+package validator
+
+import "strings"
+
+// Option 单次验证的选项，作用于 Validate、ValidateE、ValidateStruct 及 Schema 的同名方法
+// 如：v.Validate(rules, obj, "create", validator.FailFast())
+type Option func(*options)
+
+// options 单次验证的选项
+type options struct {
+	// 快速失败
+	failFast bool
+	// 严格模式
+	strict bool
+	// 返回过滤后的副本（ValidateAndClean）
+	clean bool
+	// 错误信息语言，大写，空字符串为验证器的默认语言
+	lang string
+}
+
+// FailFast 快速失败，出现第一个错误后停止验证整个场景（包括嵌套验证），返回的错误最多一个
+func FailFast() Option {
+	return func(o *options) {
+		o.failFast = true
+	}
+}
+
+// Strict 严格模式，场景的规则中未声明的属性（包括嵌套属性）报 unknown 错误，错误信息的键为具体路径，如 items.3.price
+// 规则中声明了属性路径（如 address.city）或嵌套验证规则集时检查其子属性，否则不检查该属性的值
+// 只检查 M、map 等键值对象，结构体的字段由类型定义而非调用方传入，不检查：ValidateStruct 忽略该选项，M 中嵌套的结构体不检查其字段
+func Strict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// structOnly ValidateStruct 追加的选项，忽略严格模式（见 Strict）
+func structOnly(o *options) {
+	o.strict = false
+}
+
+// WithLang 本次验证的错误信息语言，不修改验证器的默认语言（见 validator.Lang），不支持的语言将 panic（ValidateE 等返回 ErrUnsupportedLang）
+// 空字符串为验证器的默认语言，可配合 AcceptLang 使用，如 validator.WithLang(validator.AcceptLang(r.Header.Get("Accept-Language")))
+// 自定义验证器中 generator 生成的错误信息仍为验证器的默认语言
+func WithLang(lang string) Option {
+	return func(o *options) {
+		o.lang = strings.ToUpper(lang)
+	}
+}
+
+// state 单次验证的状态，每次调用 Validate 等方法时独立创建，互不干扰
+type state struct {
+	options
+	// 验证场景
+	scence Scence
+	// 快速失败模式下已出现错误，停止验证
+	stopped bool
+	// 过滤器可写入的副本（M、[]interface{} 的底层地址），见 own
+	owned map[uintptr]bool
+	// 结构体转换的 M，结构体指针 => M，见 state.object
+	views map[interface{}]M
+}
+
+// newState 根据本次验证的场景、选项构造验证状态
+func newState(scence Scence, opts []Option) *state {
+	st := &state{scence: scence}
+	for _, opt := range opts {
+		opt(&st.options)
+	}
+	if st.lang != "" {
+		if _, ok := catalogueOf(st.lang); !ok {
+			panic(&RuleError{Err: ErrUnsupportedLang, Msg: st.lang + " unsupport language"})
+		}
+	}
+	return st
+}
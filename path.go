@@ -0,0 +1,266 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 属性路径分隔符、通配符，如 address.city、items.0.sku、items.*.sku
+const (
+	PATH_SEPARATOR = "."
+	PATH_WILDCARD  = "*"
+)
+
+// attribute 待验证属性
+type attribute struct {
+	// 属性名或属性路径
+	name string
+	// 属性路径的各级属性名，非嵌套属性为 nil
+	segments []string
+}
+
+// newAttribute 构造待验证属性，包含 PATH_SEPARATOR 的视为嵌套属性路径
+func newAttribute(name string) attribute {
+	if !strings.Contains(name, PATH_SEPARATOR) {
+		return attribute{name: name}
+	}
+	return attribute{name: name, segments: strings.Split(name, PATH_SEPARATOR)}
+}
+
+// target 属性路径解析结果
+type target struct {
+	// 具体路径（已展开通配符），如 items.3.sku，用作错误信息的键
+	path string
+	// 末级属性名，如 sku
+	leaf string
+	// 末级属性所在的对象，数组元素以 M{下标: 元素} 表示，不存在时为空 M
+	obj M
+}
+
+// node 属性路径解析过程中的中间节点
+type node struct {
+	path  string
+	value interface{}
+}
+
+// resolve 解析属性路径，展开通配符，返回所有具体路径
+// 中间节点不存在时，末级属性视为不存在（以便 required 报错）；通配符匹配不到元素时，不返回任何路径
+func (this *state) resolve(obj M, segments []string) []target {
+	nodes := []node{{value: obj}}
+	last := len(segments) - 1
+	for _, segment := range segments[:last] {
+		next := make([]node, 0, len(nodes))
+		for _, n := range nodes {
+			if segment == PATH_WILDCARD {
+				for _, key := range keys(n.value) {
+					v, _ := child(n.value, key)
+					next = append(next, node{path: join(n.path, key), value: v})
+				}
+				continue
+			}
+			v, _ := child(n.value, segment)
+			next = append(next, node{path: join(n.path, segment), value: v})
+		}
+		nodes = next
+	}
+	leaf := segments[last]
+	targets := make([]target, 0, len(nodes))
+	for _, n := range nodes {
+		if leaf == PATH_WILDCARD {
+			for _, key := range keys(n.value) {
+				targets = append(targets, target{path: join(n.path, key), leaf: key, obj: this.container(n.value, key)})
+			}
+			continue
+		}
+		targets = append(targets, target{path: join(n.path, leaf), leaf: leaf, obj: this.container(n.value, leaf)})
+	}
+	return targets
+}
+
+// lookup 按属性路径取值，不支持通配符，obj 中存在同名属性时（如 "a.b"）直接返回
+func lookup(obj M, path string) (interface{}, bool) {
+	if value, ok := obj[path]; ok || !strings.Contains(path, PATH_SEPARATOR) {
+		return value, ok
+	}
+	var value interface{} = obj
+	for _, segment := range strings.Split(path, PATH_SEPARATOR) {
+		v, ok := child(value, segment)
+		if !ok {
+			return nil, false
+		}
+		value = v
+	}
+	return value, true
+}
+
+// child 取对象的属性或数组的元素
+func child(value interface{}, key string) (interface{}, bool) {
+	switch v := value.(type) {
+	case M:
+		c, ok := v[key]
+		return c, ok
+	case map[string]interface{}:
+		c, ok := v[key]
+		return c, ok
+	case []interface{}:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(v) {
+			return v[i], true
+		}
+		return nil, false
+	}
+	// 其他类型的切片/数组、结构体直接读取，不转换为 M
+	if rv, ok := array(value); ok {
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < rv.Len() {
+			return reference(rv.Index(i))
+		}
+		return nil, false
+	}
+	if rv, ok := structOf(value); ok {
+		return structField(rv, key)
+	}
+	// 其他类型的 map
+	if o, ok := object(value); ok {
+		c, ok := o[key]
+		return c, ok
+	}
+	return nil, false
+}
+
+// keys 对象的所有属性名（已排序）或数组的所有下标，其他类型返回 nil
+func keys(value interface{}) []string {
+	if rv, ok := array(value); ok {
+		return indexes(rv.Len())
+	}
+	if rv, ok := structOf(value); ok {
+		return structKeys(rv)
+	}
+	if o, ok := object(value); ok {
+		return mapKeys(o)
+	}
+	return nil
+}
+
+// container 将末级属性所在的对象或数组转换为 M，以便交给验证器验证
+func (this *state) container(value interface{}, leaf string) M {
+	if o, ok := this.object(value); ok {
+		return o
+	}
+	if c, ok := child(value, leaf); ok {
+		return M{leaf: c}
+	}
+	return M{}
+}
+
+// object 同 object，可寻址的结构体（如结构体指针、结构体切片的元素）转换的 M 在本次验证中缓存，多条规则验证同一结构体时只转换一次
+func (this *state) object(value interface{}) (M, bool) {
+	rv, ok := structOf(value)
+	if !ok || !rv.CanAddr() {
+		return object(value)
+	}
+	key := rv.Addr().Interface()
+	if o, ok := this.views[key]; ok {
+		return o, true
+	}
+	o := structToM(rv)
+	if this.views == nil {
+		this.views = make(map[interface{}]M)
+	}
+	this.views[key] = o
+	return o, true
+}
+
+// object 将对象转换为 M，非对象返回 nil, false
+// 支持 M、map[string]interface{}、键为字符串的 map、结构体及其指针（转换一层，见 ValidateStruct）
+func object(value interface{}) (M, bool) {
+	switch v := value.(type) {
+	case M:
+		return v, true
+	case map[string]interface{}:
+		return M(v), true
+	case nil:
+		return nil, false
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		return structToM(rv), true
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		o := make(M, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			if v, ok := normalize(iter.Value()); ok {
+				o[iter.Key().String()] = v
+			}
+		}
+		return o, true
+	}
+	return nil, false
+}
+
+// structOf 结构体的反射值，支持结构体及其指针，非结构体返回 false
+func structOf(value interface{}) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+// array 数组的反射值，支持任意类型的切片、数组及其指针，非数组返回 false
+func array(value interface{}) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
+
+func mapKeys(m map[string]interface{}) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+func indexes(n int) []string {
+	ks := make([]string, n)
+	for i := range ks {
+		ks[i] = strconv.Itoa(i)
+	}
+	return ks
+}
+
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + PATH_SEPARATOR + key
+}
@@ -0,0 +1,145 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+var objPath M
+
+func init() {
+	js := `{
+		"name": "hyb",
+		"address": {"city": "", "zipcode": "333000"},
+		"items": [
+			{"sku": "A001", "count": 1},
+			{"sku": 1002, "count": 0},
+			{"count": 3},
+			{"sku": "A004", "count": 1.5}
+		],
+		"a.b": "literal"
+	}`
+	if err := json.Unmarshal([]byte(js), &objPath); err != nil {
+		panic(err)
+	}
+}
+
+/***** Attr path *****/
+
+// 嵌套对象属性
+func Test_Path_Object(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address.city", Rule: "string", Min: 2},
+			{Attr: "address.zipcode", Rule: "zipcode"},
+		},
+	}
+	message := generator(v.default_errors["stringLengthMin"], "address.city", 2)
+	e := v.Validate(rules, objPath, "create")
+	// toolbox.Dump(e) // [map[address.city:长度不能小于 2]]
+	if len(e) != 1 || e[0]["address.city"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 嵌套属性不存在
+func Test_Path_Required(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: []string{"address.street", "contact.mobile", "name.first"}, Rule: "required"},
+			{Attr: "contact.email", Rule: "email"},
+		},
+	}
+	message := generator(v.default_errors["required"], "")
+	e := v.Validate(rules, objPath, "create")
+	// toolbox.Dump(e) // [map[address.street:不能为空] map[contact.mobile:不能为空] map[name.first:不能为空]]
+	if len(e) != 3 || e[0]["address.street"] != message || e[1]["contact.mobile"] != message || e[2]["name.first"] != message {
+		fail(t, "should print 3 errors("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 数组下标
+func Test_Path_Index(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: []string{"items.0.sku", "items.1.sku"}, Rule: "string"},
+			{Attr: "items.9.sku", Rule: "string", Required: true},
+		},
+	}
+	e := v.Validate(rules, objPath, "create")
+	// toolbox.Dump(e) // [map[items.1.sku:必须是字符串] map[items.9.sku:不能为空]]
+	if len(e) != 2 || e[0]["items.1.sku"] != v.default_errors["string"] || e[1]["items.9.sku"] != v.default_errors["required"] {
+		fail(t, "should print errors of items.1.sku and items.9.sku, got "+fmt.Sprint(e))
+	}
+}
+
+// 通配符，错误信息的键为具体路径
+func Test_Path_Wildcard(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items.*.sku", Rule: "string", Required: true},
+			{Attr: "items.*.count", Rule: "int", Symbol: 1},
+		},
+	}
+	want := fmt.Sprint([]E{
+		{"items.1.sku": v.default_errors["string"]},
+		{"items.2.sku": v.default_errors["required"]},
+		{"items.1.count": v.default_errors["integerPositive"]},
+		{"items.3.count": v.default_errors["integer"]},
+	})
+	e := v.Validate(rules, objPath, "create")
+	// toolbox.Dump(e)
+	if fmt.Sprint(e) != want {
+		fail(t, "should print "+want+", got "+fmt.Sprint(e))
+	}
+}
+
+// 通配符作为末级属性
+func Test_Path_Wildcard_Leaf(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address.*", Rule: "string", Max: 3},
+			{Attr: "tags.*", Rule: "required"},
+		},
+	}
+	message := generator(v.default_errors["stringLengthMax"], "address.zipcode", 3)
+	e := v.Validate(rules, objPath, "create")
+	// toolbox.Dump(e) // [map[address.zipcode:长度不能超过 3]]
+	if len(e) != 1 || e[0]["address.zipcode"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 同名属性优先
+func Test_Path_Literal(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "a.b", Rule: "int"},
+		},
+	}
+	e := v.Validate(rules, objPath, "create")
+	// toolbox.Dump(e) // [map[a.b:必须是整数]]
+	if len(e) != 1 || e[0]["a.b"] != v.default_errors["integer"] {
+		fail(t, "should print error("+v.default_errors["integer"]+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 自定义验证函数以末级属性所在的对象为 obj
+func Test_Path_Func(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items.*.count", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				if _, ok := obj["sku"]; !ok {
+					return E{attr: "缺少 sku"}
+				}
+				return nil
+			}},
+		},
+	}
+	e := v.Validate(rules, objPath, "create")
+	// toolbox.Dump(e) // [map[items.2.count:缺少 sku]]
+	if len(e) != 1 || e[0]["items.2.count"] != "缺少 sku" {
+		fail(t, "should print error(缺少 sku), got "+fmt.Sprint(e))
+	}
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// 默认场景，其规则作用于所有场景（置于场景自身的规则之前）
+	SCENCE_DEFAULT Scence = "*"
+
+	// 场景继承，Rule.Attr 为被继承的场景，单个场景 string，多个场景 []string，被继承场景的规则将在该位置展开
+	// 如 {Attr: "create", Rule: "extends"}
+	RULE_EXTENDS = "extends"
+	// 场景继承，同 RULE_EXTENDS，但去除被继承规则的必填限制（移除 required 规则，Rule.Required 置为 false），嵌套验证规则集不受影响
+	// 如 {Attr: "create", Rule: "extends_optional"}
+	RULE_EXTENDS_OPTIONAL = "extends_optional"
+)
+
+// scenceRulesOf 场景的完整验证规则集，依次为默认场景的规则、展开场景继承后的场景自身的规则
+// 场景（包括被继承的场景）不存在、循环继承将 panic
+func scenceRulesOf(rules Rules, scence Scence) ScenceRules {
+	if _, ok := rules[scence]; !ok {
+		panic(&RuleError{Err: ErrSceneUndefined, Msg: fmt.Sprint(scence) + " scence undefined"})
+	}
+	own := expand(rules, scence, nil)
+	defaults, ok := rules[SCENCE_DEFAULT]
+	if !ok || scence == SCENCE_DEFAULT {
+		return own
+	}
+	defaults = expand(rules, SCENCE_DEFAULT, nil)
+	return append(append(make(ScenceRules, 0, len(defaults)+len(own)), defaults...), own...)
+}
+
+// expand 展开场景继承，visiting 为正在展开的场景链，用于检测循环继承
+// 未使用场景继承时直接返回场景的规则集
+func expand(rules Rules, scence Scence, visiting []Scence) ScenceRules {
+	scenceRules := rules[scence]
+	if !extended(scenceRules) {
+		return scenceRules
+	}
+	for _, s := range visiting {
+		if s == scence {
+			chain := make([]string, 0, len(visiting)+1)
+			for _, v := range append(visiting, scence) {
+				chain = append(chain, string(v))
+			}
+			panic(&RuleError{Err: ErrInvalidRule, Msg: "scence extends cycle: " + strings.Join(chain, " -> ")})
+		}
+	}
+	visiting = append(visiting, scence)
+	expanded := make(ScenceRules, 0, len(scenceRules))
+	for _, rule := range scenceRules {
+		if rule.Rule != RULE_EXTENDS && rule.Rule != RULE_EXTENDS_OPTIONAL {
+			expanded = append(expanded, rule)
+			continue
+		}
+		for _, parent := range parents(rule) {
+			if _, ok := rules[parent]; !ok {
+				panic(&RuleError{Err: ErrSceneUndefined, Rule: rule, Msg: fmt.Sprint(scence) + " extends " + fmt.Sprint(parent) + ", " + fmt.Sprint(parent) + " scence undefined"})
+			}
+			inherited := expand(rules, parent, visiting)
+			if rule.Rule == RULE_EXTENDS_OPTIONAL {
+				inherited = optional(inherited)
+			}
+			expanded = append(expanded, inherited...)
+		}
+	}
+	return expanded
+}
+
+// extended 规则集是否使用了场景继承
+func extended(scenceRules ScenceRules) bool {
+	for _, rule := range scenceRules {
+		if rule.Rule == RULE_EXTENDS || rule.Rule == RULE_EXTENDS_OPTIONAL {
+			return true
+		}
+	}
+	return false
+}
+
+// parents 场景继承规则的被继承场景，Rule.Attr 类型错误将 panic
+func parents(rule Rule) []Scence {
+	switch attr := rule.Attr.(type) {
+	case string:
+		return []Scence{Scence(attr)}
+	case []string:
+		scences := make([]Scence, 0, len(attr))
+		for _, a := range attr {
+			scences = append(scences, Scence(a))
+		}
+		return scences
+	case nil:
+		panic(newRuleError(ErrInvalidRule, rule, "attribute 'Attr' not found"))
+	}
+	panic(&RuleError{Err: ErrInvalidRule, Rule: rule, Msg: "attribute 'Attr' should be 'string' or '[]string'"})
+}
+
+// optional 去除规则集的必填限制，移除 required 规则，Rule.Required 置为 false
+func optional(scenceRules ScenceRules) ScenceRules {
+	rules := make(ScenceRules, 0, len(scenceRules))
+	for _, rule := range scenceRules {
+		if rule.Rule == "required" {
+			continue
+		}
+		rule.Required = false
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,184 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+var scenceRules = Rules{
+	SCENCE_DEFAULT: {
+		{Attr: "email", Rule: "email"},
+	},
+	"create": {
+		{Attr: []string{"username", "password"}, Rule: "required"},
+		{Attr: "username", Rule: "string", Min: 3, Max: 18},
+		{Attr: "age", Rule: "int", Min: 18, Required: true},
+	},
+	"update": {
+		{Attr: "create", Rule: RULE_EXTENDS_OPTIONAL},
+		{Attr: "id", Rule: "int", Symbol: 1, Required: true},
+	},
+	"import": {
+		{Attr: "create", Rule: RULE_EXTENDS},
+		{Attr: "source", Rule: "required"},
+	},
+}
+
+/***** 场景继承 *****/
+
+// 继承被继承场景的规则，并追加自身的规则
+func Test_Scence_Extends(t *testing.T) {
+	e := v.Validate(scenceRules, M{"username": "hy", "age": 17}, "import")
+	// toolbox.Dump(e) // [map[password:不能为空] map[username:长度必须在 3 到 18 之间] map[age:必须是不小于 18 的整数] map[source:不能为空]]
+	if len(e) != 4 || e[0]["password"] == "" || e[1]["username"] == "" || e[2]["age"] == "" || e[3]["source"] == "" {
+		fail(t, "should print 4 errors(password、username、age、source), got "+fmt.Sprint(e))
+	}
+}
+
+// 去除必填限制，有值仍需验证
+func Test_Scence_Extends_Optional(t *testing.T) {
+	e := v.Validate(scenceRules, M{"id": 1}, "update")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	e = v.Validate(scenceRules, M{"username": "hy"}, "update")
+	// toolbox.Dump(e) // [map[username:长度必须在 3 到 18 之间] map[id:不能为空]]
+	if len(e) != 2 || e[0]["username"] == "" || e[1]["id"] == "" {
+		fail(t, "should print 2 errors(username、id), got "+fmt.Sprint(e))
+	}
+	// 原场景不受影响
+	if e := v.Validate(scenceRules, M{"id": 1}, "create"); len(e) != 3 {
+		fail(t, "should print 3 errors, got "+fmt.Sprint(e))
+	}
+}
+
+// 多级继承、同时继承多个场景
+func Test_Scence_Extends_Multi(t *testing.T) {
+	rules := Rules{
+		"base":   {{Attr: "a", Rule: "required"}},
+		"middle": {{Attr: "base", Rule: RULE_EXTENDS}, {Attr: "b", Rule: "required"}},
+		"other":  {{Attr: "c", Rule: "required"}},
+		"top":    {{Attr: []string{"middle", "other"}, Rule: RULE_EXTENDS}},
+	}
+	e := v.Validate(rules, objEmpty, "top")
+	// toolbox.Dump(e) // [map[a:不能为空] map[b:不能为空] map[c:不能为空]]
+	if len(e) != 3 || e[0]["a"] == "" || e[1]["b"] == "" || e[2]["c"] == "" {
+		fail(t, "should print 3 errors(a、b、c), got "+fmt.Sprint(e))
+	}
+}
+
+// 循环继承
+func Test_Scence_Extends_Cycle(t *testing.T) {
+	rules := Rules{
+		"a": {{Attr: "b", Rule: RULE_EXTENDS}},
+		"b": {{Attr: "c", Rule: RULE_EXTENDS_OPTIONAL}},
+		"c": {{Attr: "a", Rule: RULE_EXTENDS}},
+	}
+	_, err := v.ValidateE(rules, objEmpty, "a")
+	// toolbox.Dump(err) // scence extends cycle: a -> b -> c -> a
+	if !errors.Is(err, ErrInvalidRule) || err.Error() != "scence extends cycle: a -> b -> c -> a" {
+		fail(t, "should return error(scence extends cycle: a -> b -> c -> a), got "+fmt.Sprint(err))
+	}
+	if _, err := v.Compile(Rules{"a": {{Attr: "a", Rule: RULE_EXTENDS}}}); !errors.Is(err, ErrInvalidRule) {
+		fail(t, "should return error(invalid rule), got "+fmt.Sprint(err))
+	}
+}
+
+// 被继承的场景不存在、Rule.Attr 类型错误
+func Test_Scence_Extends_RuleErr(t *testing.T) {
+	if _, err := v.ValidateE(Rules{"update": {{Attr: "create", Rule: RULE_EXTENDS}}}, objEmpty, "update"); !errors.Is(err, ErrSceneUndefined) {
+		fail(t, "should return error(scence undefined), got "+fmt.Sprint(err))
+	}
+	for _, attr := range []interface{}{nil, 1, Scence("create")} {
+		rules := Rules{"create": {}, "update": {{Attr: attr, Rule: RULE_EXTENDS}}}
+		if _, err := v.ValidateE(rules, objEmpty, "update"); !errors.Is(err, ErrInvalidRule) {
+			fail(t, "should return error(invalid rule), got "+fmt.Sprint(err))
+		}
+	}
+}
+
+/***** 默认场景 *****/
+
+// 默认场景的规则作用于所有场景，且位于场景自身的规则之前，继承时不会重复
+func Test_Scence_Default(t *testing.T) {
+	schema := v.MustCompile(scenceRules)
+	obj := M{"email": "hyb76788424#163.com", "username": "hyb", "password": "******", "age": 18, "id": 1, "source": "csv"}
+	for _, scence := range []Scence{SCENCE_DEFAULT, "create", "update", "import"} {
+		e := schema.Validate(obj, scence)
+		// toolbox.Dump(e) // [map[email:无效的 email]]
+		if len(e) != 1 || e[0]["email"] != v.default_errors["email"] {
+			fail(t, string(scence)+" should print error of email only, got "+fmt.Sprint(e))
+		}
+	}
+}
+
+// 默认场景不能代替未定义的场景
+func Test_Scence_Default_Undefined_Scence(t *testing.T) {
+	if _, err := v.ValidateE(scenceRules, objEmpty, "delete"); !errors.Is(err, ErrSceneUndefined) {
+		fail(t, "should return error(scence undefined), got "+fmt.Sprint(err))
+	}
+}
+
+/***** Rule.On、Rule.Except *****/
+
+var flatRules = ScenceRules{
+	{Attr: []string{"username", "password"}, Rule: "required", On: []Scence{"create"}},
+	{Attr: "username", Rule: "string", Min: 3, Max: 18},
+	{Attr: "id", Rule: "int", Symbol: 1, Required: true, Except: []Scence{"create"}},
+	{Attr: "items", Rule: "each", Rules: ScenceRules{
+		{Attr: "sku", Rule: "required", On: []Scence{"create", "import"}, Except: []Scence{"import"}},
+	}},
+}
+
+// 扁平规则列表，规则适用的场景由 Rule.On、Rule.Except 决定，嵌套验证规则集同样适用
+func Test_ValidateFlat(t *testing.T) {
+	obj := M{"username": "hy", "items": []interface{}{M{}}}
+	e := v.ValidateFlat(flatRules, obj, "create")
+	// toolbox.Dump(e) // [map[password:不能为空] map[username:长度必须在 3 到 18 之间] map[items.0.sku:不能为空]]
+	if len(e) != 3 || e[0]["password"] == "" || e[1]["username"] == "" || e[2]["items.0.sku"] == "" {
+		fail(t, "should print 3 errors(password、username、items.0.sku), got "+fmt.Sprint(e))
+	}
+	// Rule.Except 优先于 Rule.On
+	e = v.ValidateFlat(flatRules, obj, "import")
+	// toolbox.Dump(e) // [map[username:长度必须在 3 到 18 之间] map[id:不能为空]]
+	if len(e) != 2 || e[0]["username"] == "" || e[1]["id"] == "" {
+		fail(t, "should print 2 errors(username、id), got "+fmt.Sprint(e))
+	}
+}
+
+// 预编译扁平规则列表，任意场景均可验证
+func Test_CompileFlat(t *testing.T) {
+	schema := v.MustCompileFlat(flatRules)
+	obj := M{"username": "hyb", "password": "******", "id": 1}
+	for _, scence := range []Scence{"create", "update", "delete"} {
+		if e := schema.Validate(obj, scence); len(e) != 0 {
+			fail(t, string(scence)+" should print nothing, got "+fmt.Sprint(e))
+		}
+	}
+	if e := schema.Validate(objEmpty, "update"); len(e) != 1 || e[0]["id"] == "" {
+		fail(t, "should print error of id, got "+fmt.Sprint(e))
+	}
+	if _, err := v.CompileFlat(ScenceRules{{Attr: "id", Rule: "undefined"}}); !errors.Is(err, ErrValidatorUndefined) {
+		fail(t, "should return error(validator undefined), got "+fmt.Sprint(err))
+	}
+}
+
+// 场景规则集中同样适用
+func Test_Rule_On_Rules(t *testing.T) {
+	rules := Rules{
+		SCENCE_DEFAULT: {
+			{Attr: "id", Rule: "required", Except: []Scence{"create"}},
+		},
+		"create": {},
+		"update": {},
+	}
+	if e := v.Validate(rules, objEmpty, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if e := v.Validate(rules, objEmpty, "update"); len(e) != 1 {
+		fail(t, "should print error of id, got "+fmt.Sprint(e))
+	}
+}
@@ -0,0 +1,324 @@
+package validator
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Schema 预编译的验证规则集，由 Compile 生成，并发安全
+// 规则定义在编译时一次性检查完毕，验证时不再检查规则参数、查找验证器、编译正则
+type Schema struct {
+	validator *validator
+	scences   map[Scence][]compiledRule
+	// 扁平规则列表（CompileFlat），适用于所有场景
+	flat []compiledRule
+}
+
+// compiledRule 编译后的验证规则
+type compiledRule struct {
+	// 验证器
+	f validatorFunc
+	// 过滤器，为 nil 时为验证器
+	filter filter
+	// 验证规则，已包含预解析的规则参数
+	rule Rule
+	// 待验证属性
+	attrs []attribute
+	// 嵌套验证规则集，作用于 objectValidator、eachValidator
+	children []compiledRule
+	// 是否写入属性的值（过滤器、包含过滤器的嵌套验证规则集），写入前将经过的对象、数组替换为副本（见 writable）
+	writes bool
+}
+
+// params 预解析的内置验证器规则参数
+type params struct {
+	// Rule.Max/Rule.Min 原始值，用于生成错误信息
+	max, min interface{}
+	// Rule.Max/Rule.Min 转换为 float64 后的值，用于比较
+	fmax, fmin float64
+	// Rule.Pattern 编译后的正则
+	regex *regexp.Regexp
+	// Rule.Other 转换为 []string 后的值
+	others []string
+	// Rule.Layout 转换为 []string 后的值
+	layouts []string
+	// Rule.Timezone 加载后的时区
+	loc *time.Location
+	// Rule.Before/Rule.After 解析后的边界
+	before, after *bound
+	// Rule.Schemes 转换为小写后的值
+	schemes []string
+	// Rule.Hosts/Rule.BlockHosts 转换为小写后的值
+	hosts, blockHosts []string
+	// Rule.Ports 解析后的端口范围
+	ports [][2]int
+	// Rule.Networks 解析后的网段
+	networks []*net.IPNet
+}
+
+// parsers 内置验证器的规则参数解析器，规则参数错误将 panic
+var parsers = map[string]func(Rule) *params{
+	"func":    parseFunc,
+	"default": parseDefault,
+	// 条件必填
+	"required_if":      parseRequiredIf,
+	"required_unless":  parseRequiredIf,
+	"required_with":    parseRequiredWith,
+	"required_without": parseRequiredWith,
+	// 字段比较
+	"same":      parseField,
+	"different": parseField,
+	"gt_field":  parseField,
+	"gte_field": parseField,
+	"lt_field":  parseField,
+	"lte_field": parseField,
+	"in":        parseIn,
+	"string":    parseString,
+	"integer":   parseInteger,
+	"decimal":   parseNumber,
+	"number":    parseNumber,
+	"regex":     parseRegex,
+	"date":      parseDate,
+	"datetime":  parseDate,
+	"time":      parseDate,
+	"url":       parseURL,
+	"ip":        parseIP,
+	"ipv4":      parseIP,
+	"ipv6":      parseIP,
+	"port":      parsePort,
+	"host_port": parsePort,
+	"email":     parsePattern(PATTERN_EMAIL),
+	"tel":       parsePattern(PATTERN_TEL),
+	"mobile":    parsePattern(PATTERN_MOBILE),
+	"zipcode":   parsePattern(PATTERN_ZIPCODE),
+	"object":    parseRules,
+	"each":      parseRules,
+	// 别名
+	"int":   parseInteger, // integer
+	"float": parseNumber,  // decimal
+}
+
+// Compile 编译验证规则集，一次性检查所有场景的规则定义、展开场景继承、查找验证器、预解析规则参数、预编译正则
+// 规则定义错误返回 *RuleError，编译后通过 AddValidator 添加的验证器不会影响已编译的 Schema
+func (this *validator) Compile(rules Rules) (schema *Schema, err error) {
+	defer recoverRuleError(&err)
+	scences := make(map[Scence][]compiledRule, len(rules))
+	for scence := range rules {
+		scences[scence] = this.compile(scenceRulesOf(rules, scence))
+	}
+	return &Schema{validator: this, scences: scences}, nil
+}
+
+// MustCompile 同 Compile，规则定义错误将 panic
+func (this *validator) MustCompile(rules Rules) *Schema {
+	schema, err := this.Compile(rules)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// CompileFlat 编译扁平规则列表，同 Compile，编译后的 Schema 可验证任意场景，每条规则适用的场景由 Rule.On、Rule.Except 决定
+func (this *validator) CompileFlat(rules ScenceRules) (schema *Schema, err error) {
+	defer recoverRuleError(&err)
+	return &Schema{validator: this, flat: this.compile(rules)}, nil
+}
+
+// MustCompileFlat 同 CompileFlat，规则定义错误将 panic
+func (this *validator) MustCompileFlat(rules ScenceRules) *Schema {
+	schema, err := this.CompileFlat(rules)
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}
+
+// Validate 场景验证，场景不存在将 panic（CompileFlat 编译的 Schema 除外），opts 同 validator.Validate
+func (this *Schema) Validate(obj M, scence Scence, opts ...Option) []E {
+	_, errs := this.validator.exec(this.compiled(scence), obj, newState(scence, opts))
+	return errs.E()
+}
+
+// ValidateE 场景验证，同 Validate，场景不存在以 error 返回
+func (this *Schema) ValidateE(obj M, scence Scence, opts ...Option) (errs []E, err error) {
+	defer recoverRuleError(&err)
+	return this.Validate(obj, scence, opts...), nil
+}
+
+// Check 场景验证，同 validator.Check
+func (this *Schema) Check(obj M, scence Scence, opts ...Option) (err error) {
+	defer recoverRuleError(&err)
+	_, errs := this.validator.exec(this.compiled(scence), obj, newState(scence, opts))
+	return errs.err()
+}
+
+// compiled 场景的已编译规则集，场景不存在将 panic，CompileFlat 编译的 Schema 返回扁平规则列表
+func (this *Schema) compiled(scence Scence) []compiledRule {
+	compiled, ok := this.scences[scence]
+	if !ok {
+		if this.flat == nil {
+			panic(&RuleError{Err: ErrSceneUndefined, Msg: fmt.Sprint(scence) + " scence undefined"})
+		}
+		compiled = this.flat
+	}
+	return compiled
+}
+
+// paramsOf 获取规则参数，已预解析直接返回，否则使用 parse 解析
+func paramsOf(rule Rule, parse func(Rule) *params) *params {
+	if rule.params != nil {
+		return rule.params
+	}
+	return parse(rule)
+}
+
+// parseFunc funcValidator 规则参数，Rule.Func 必选
+func parseFunc(rule Rule) *params {
+	if rule.Func == nil {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Func' not found or empty"))
+	}
+	return &params{}
+}
+
+// parseDefault default 过滤器规则参数，Rule.Default 必选
+func parseDefault(rule Rule) *params {
+	if rule.Default == nil {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Default' not found"))
+	}
+	return &params{}
+}
+
+// parseIn inValidator 规则参数，Rule.Enum 必选
+func parseIn(rule Rule) *params {
+	if len(rule.Enum) == 0 {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Enum' not found or empty"))
+	}
+	return &params{}
+}
+
+// parseRules objectValidator、eachValidator 规则参数，Rule.Rules 必选
+func parseRules(rule Rule) *params {
+	if len(rule.Rules) == 0 {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Rules' not found or empty"))
+	}
+	return &params{}
+}
+
+// parseRequiredIf requiredIfValidator、requiredUnlessValidator 规则参数，Rule.Other 必选且只能是 string，Rule.Enum 必选
+func parseRequiredIf(rule Rule) *params {
+	other, ok := rule.Other.(string)
+	if !ok || other == "" {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Other' should be string"))
+	}
+	if len(rule.Enum) == 0 {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Enum' not found or empty"))
+	}
+	return &params{others: []string{other}}
+}
+
+// parseRequiredWith requiredWithValidator、requiredWithoutValidator 规则参数，Rule.Other 必选，可以是 string 或 []string
+func parseRequiredWith(rule Rule) *params {
+	var others []string
+	switch v := rule.Other.(type) {
+	case string:
+		if v != "" {
+			others = []string{v}
+		}
+	case []string:
+		others = v
+	}
+	if len(others) == 0 {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Other' should be string or []string"))
+	}
+	return &params{others: others}
+}
+
+// parseField 字段比较验证器（sameValidator、gtFieldValidator 等）规则参数，Rule.Other 必选且只能是 string
+func parseField(rule Rule) *params {
+	other, ok := rule.Other.(string)
+	if !ok || other == "" {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Other' should be string"))
+	}
+	return &params{others: []string{other}}
+}
+
+// parseString stringValidator 规则参数，Rule.Max/Rule.Min 只能是 int
+func parseString(rule Rule) *params {
+	return parseBounds(rule, true)
+}
+
+// parseInteger integerValidator 规则参数，Rule.Max/Rule.Min 只能是 int，且需与 Rule.Symbol 一致
+func parseInteger(rule Rule) *params {
+	return parseSymbol(rule, parseBounds(rule, true))
+}
+
+// parseNumber numberValidator、decimalValidator 规则参数，Rule.Max/Rule.Min 可以是 int 或 float64，且需与 Rule.Symbol 一致
+func parseNumber(rule Rule) *params {
+	return parseSymbol(rule, parseBounds(rule, false))
+}
+
+// parseRegex regexValidator 规则参数，Rule.Pattern 必选，且必须是合法的正则
+func parseRegex(rule Rule) *params {
+	if rule.Pattern == "" {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Pattern' not found or empty"))
+	}
+	regex, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Pattern' "+err.Error()))
+	}
+	return &params{regex: regex}
+}
+
+// parsePattern 使用内置正则的验证器（emailValidator 等）的规则参数
+func parsePattern(pattern string) func(Rule) *params {
+	regex := regexp.MustCompile(pattern)
+	return func(Rule) *params {
+		return &params{regex: regex}
+	}
+}
+
+// parseBounds 解析 Rule.Max/Rule.Min，integer 为 true 时只能是 int，否则可以是 int 或 float64
+func parseBounds(rule Rule, integer bool) *params {
+	p := &params{max: rule.Max, min: rule.Min}
+	if p.max != nil {
+		p.fmax = parseBound(rule, "Max", p.max, integer)
+	}
+	if p.min != nil {
+		p.fmin = parseBound(rule, "Min", p.min, integer)
+	}
+	if p.max != nil && p.min != nil && p.fmin > p.fmax {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Max' should greater than 'Min'"))
+	}
+	return p
+}
+
+// parseBound 解析 Rule.Max 或 Rule.Min
+func parseBound(rule Rule, name string, bound interface{}, integer bool) float64 {
+	switch v := bound.(type) {
+	case int:
+		return float64(v)
+	case float64:
+		if !integer {
+			return v
+		}
+	}
+	if integer {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute '"+name+"' should be int"))
+	}
+	panic(newRuleError(ErrInvalidRuleParam, rule, "attribute '"+name+"' should be int or float64"))
+}
+
+// parseSymbol 检查 Rule.Max/Rule.Min 与 Rule.Symbol 是否矛盾
+func parseSymbol(rule Rule, p *params) *params {
+	symbol := rule.Symbol
+	if symbol > 0 && p.max != nil && p.fmax <= 0 { // 要求被检测属性是正数，而最大值被设置成负数，panic
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Max' should greater than 0 when 'Symbal' = "+strconv.FormatInt(symbol, 10)))
+	}
+	if symbol < 0 && p.min != nil && p.fmin >= 0 { // 要求被检测属性是负数，而最小值被设置成正数，panic
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Min' should less than 0 when 'Symbal' = "+strconv.FormatInt(symbol, 10)))
+	}
+	return p
+}
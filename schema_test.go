@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var rulesSchema = Rules{
+	"create": {
+		{Attr: []string{"username", "password"}, Rule: "required"},
+		{Attr: "username", Rule: "string", Min: 3, Max: 18},
+		{Attr: "password", Rule: "regex", Pattern: `^[A-Z]\w{5,}$`},
+		{Attr: "gender", Rule: "in", Enum: []string{"male", "female"}},
+		{Attr: "age", Rule: "int", Symbol: 1, Min: 18, Max: 150},
+		{Attr: "weight", Rule: "number", Min: 2.5, Max: 300},
+		{Attr: "email", Rule: "email"},
+		{Attr: "mobile", Rule: "mobile"},
+	},
+	"read": {
+		{Attr: "id", Rule: "int", Symbol: 1},
+	},
+}
+
+var objSchema = M{
+	"username": "hyb",
+	"password": "Abc123456",
+	"gender":   "male",
+	"age":      float64(18),
+	"weight":   "53.5",
+	"email":    "hyb76788424@163.com",
+	"mobile":   "15990573367",
+}
+
+/***** Compile() *****/
+
+// 编译，验证结果与 Validate 一致
+func Test_Compile(t *testing.T) {
+	schema, err := v.Compile(rulesSchema)
+	if err != nil {
+		fail(t, "should return nil error, got "+err.Error())
+		return
+	}
+	objs := []M{
+		objSchema,
+		objEmpty,
+		{"username": "hy", "password": "abc", "gender": "unknown", "age": -1, "weight": 1, "email": "hyb#163.com", "mobile": "123"},
+	}
+	for _, obj := range objs {
+		want := fmt.Sprint(v.Validate(rulesSchema, obj, "create"))
+		got := fmt.Sprint(schema.Validate(obj, "create"))
+		// toolbox.Dump(got)
+		if got != want {
+			fail(t, "should print "+want+", got "+got)
+		}
+	}
+}
+
+// 编译时检查所有场景的规则定义
+func Test_Compile_RuleError(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules Rules
+		err   error
+	}{
+		{"undefined rule", Rules{"create": {{Attr: "username", Rule: "undefined_rule"}}}, ErrValidatorUndefined},
+		{"notfound attr", Rules{"read": {{Rule: "required"}}}, ErrInvalidRule},
+		{"notfound enum", Rules{"create": {{Attr: "gender", Rule: "in"}}}, ErrInvalidRuleParam},
+		{"invalid pattern", Rules{"create": {{Attr: "password", Rule: "regex", Pattern: `[A-Z`}}}, ErrInvalidRuleParam},
+		{"typeerr min", Rules{"create": {{Attr: "username", Rule: "string", Min: 3.5}}}, ErrInvalidRuleParam},
+		{"logicerr symbol", Rules{"create": {{Attr: "age", Rule: "int", Symbol: -1, Min: 1}}}, ErrInvalidRuleParam},
+	}
+	for _, c := range cases {
+		schema, err := v.Compile(c.rules)
+		// toolbox.Dump(err)
+		if !errors.Is(err, c.err) || schema != nil {
+			fail(t, c.name+": should return error("+c.err.Error()+"), got "+fmt.Sprint(err))
+		}
+	}
+}
+
+// 规则定义错误，MustCompile panic
+func Test_MustCompile_RuleError(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrValidatorUndefined) {
+			fail(t, "should panic(undefined_rule validator undefined)")
+		}
+	}()
+	v.MustCompile(Rules{"create": {{Attr: "username", Rule: "undefined_rule"}}})
+}
+
+// 未定义场景
+func Test_Schema_Undefined_Scence(t *testing.T) {
+	schema := v.MustCompile(rulesSchema)
+	if _, err := schema.ValidateE(objEmpty, "undefinde_scence"); !errors.Is(err, ErrSceneUndefined) {
+		fail(t, "should return error(undefinde_scence scence undefined)")
+	}
+	defer func() {
+		if recover() == nil {
+			fail(t, "should panic(undefinde_scence scence undefined)")
+		}
+	}()
+	schema.Validate(objEmpty, "undefinde_scence")
+}
+
+/***** Benchmark *****/
+
+func Benchmark_Validate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v.Validate(rulesSchema, objSchema, "create")
+	}
+}
+
+func Benchmark_Schema_Validate(b *testing.B) {
+	schema := v.MustCompile(rulesSchema)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		schema.Validate(objSchema, "create")
+	}
+}
@@ -0,0 +1,141 @@
+package validator
+
+// fields 场景声明的属性树，键为属性名（数组元素为下标或 PATH_WILDCARD），值为子属性树
+// 子属性树为空表示该属性下未声明子属性，其值不再检查
+type fields map[string]fields
+
+// declared 已编译规则集中适用于场景的属性树，包括嵌套属性路径及嵌套验证规则集中的属性
+func declared(compiled []compiledRule, scence Scence) fields {
+	tree := fields{}
+	declare(tree, compiled, scence)
+	return tree
+}
+
+// declare 将规则集中的属性加入属性树
+func declare(tree fields, compiled []compiledRule, scence Scence) {
+	for _, c := range compiled {
+		if !applicable(c.rule, scence) {
+			continue
+		}
+		for _, attr := range c.attrs {
+			segments := attr.segments
+			if segments == nil {
+				segments = []string{attr.name}
+			} else {
+				// obj 中存在同名属性时（如 "a.b"）按单个属性处理
+				tree.add([]string{attr.name})
+			}
+			node := tree.add(segments)
+			if c.children == nil {
+				continue
+			}
+			if c.rule.Rule == "each" {
+				node = node.add([]string{PATH_WILDCARD})
+			}
+			declare(node, c.children, scence)
+		}
+	}
+}
+
+// add 按属性路径逐级加入属性树，返回末级属性的子属性树
+func (this fields) add(segments []string) fields {
+	node := this
+	for _, segment := range segments {
+		next, ok := node[segment]
+		if !ok {
+			next = fields{}
+			node[segment] = next
+		}
+		node = next
+	}
+	return node
+}
+
+// lookup 属性对应的子属性树，未声明的属性 ok 为 false，匹配不到时使用 PATH_WILDCARD
+func (this fields) lookup(key string) (sub fields, ok bool) {
+	if sub, ok = this[key]; ok {
+		return sub, true
+	}
+	sub, ok = this[PATH_WILDCARD]
+	return sub, ok
+}
+
+// unknown 未声明的属性的具体路径（已排序），value 为对象或数组，path 为其路径，结构体不检查
+func (this fields) unknown(value interface{}, path string) []string {
+	if _, ok := structOf(value); ok {
+		return nil
+	}
+	var paths []string
+	for _, key := range keys(value) {
+		sub, ok := this.lookup(key)
+		if !ok {
+			paths = append(paths, join(path, key))
+			continue
+		}
+		if len(sub) == 0 { // 未声明子属性
+			continue
+		}
+		c, _ := child(value, key)
+		paths = append(paths, sub.unknown(c, join(path, key))...)
+	}
+	return paths
+}
+
+// filter 按属性树过滤对象或数组，返回副本，对象转换为 M，数组转换为 []interface{}，未声明子属性的值原样保留
+func (this fields) filter(value interface{}) interface{} {
+	if _, ok := array(value); ok {
+		filtered := make([]interface{}, 0)
+		for _, key := range keys(value) {
+			if sub, ok := this.lookup(key); ok {
+				c, _ := child(value, key)
+				filtered = append(filtered, sub.filterValue(c))
+			}
+		}
+		return filtered
+	}
+	o, ok := object(value)
+	if !ok {
+		return value
+	}
+	filtered := make(M, len(o))
+	for key, c := range o {
+		if sub, ok := this.lookup(key); ok {
+			filtered[key] = sub.filterValue(c)
+		}
+	}
+	return filtered
+}
+
+// filterValue 过滤属性的值，未声明子属性时原样返回
+func (this fields) filterValue(value interface{}) interface{} {
+	if len(this) == 0 {
+		return value
+	}
+	return this.filter(value)
+}
+
+// strict 严格模式，未声明的属性报 unknown 错误
+func (this *validator) strict(errs Errors, compiled []compiledRule, obj M, st *state) Errors {
+	for _, path := range declared(compiled, st.scence).unknown(obj, "") {
+		if st.stopped {
+			break
+		}
+		e := this.failure("unknown", path, Rule{})
+		e[0].Value, _ = lookup(obj, path)
+		errs = append(errs, e...)
+		st.stopped = st.failFast
+	}
+	return errs
+}
+
+// SafeAttributes 安全属性，返回 obj 的副本，仅包含场景（包括默认场景、继承的场景）的规则中声明的属性，嵌套属性路径、嵌套验证规则集中的属性同样过滤
+// 对象转换为 M，数组转换为 []interface{}，规则中未声明子属性的值原样保留（不复制）
+// 规则定义错误将 panic
+func (this *validator) SafeAttributes(rules Rules, obj M, scence Scence) M {
+	return declared(this.compile(scenceRulesOf(rules, scence)), scence).filter(obj).(M)
+}
+
+// SafeAttributes 安全属性，同 validator.SafeAttributes，场景不存在将 panic（CompileFlat 编译的 Schema 除外）
+func (this *Schema) SafeAttributes(obj M, scence Scence) M {
+	return declared(this.compiled(scence), scence).filter(obj).(M)
+}
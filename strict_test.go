@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+var strictRules = Rules{
+	"create": {
+		{Attr: []string{"username", "password"}, Rule: "required"},
+		{Attr: "address.city", Rule: "string"},
+		{Attr: "items", Rule: "each", Rules: ScenceRules{
+			{Attr: "sku", Rule: "required"},
+			{Attr: "count", Rule: "int", On: []Scence{"update"}},
+		}},
+		{Attr: "tags", Rule: "each", Rules: ScenceRules{
+			{Attr: "name", Rule: "string"},
+		}},
+		{Attr: "extra", Rule: "required"},
+	},
+}
+
+/***** Strict() *****/
+
+// 未声明的属性（包括嵌套属性）报 unknown 错误，错误信息的键为具体路径
+func Test_Validate_Strict(t *testing.T) {
+	obj := M{
+		"username": "hyb",
+		"password": "******",
+		"is_admin": true,
+		"address":  M{"city": "hz", "street": "x"},
+		"items":    []interface{}{M{"sku": "a"}, M{"sku": "b", "count": 1}},
+		"extra":    M{"anything": 1}, // 未声明子属性，不检查
+	}
+	e := v.Validate(strictRules, obj, "create", Strict())
+	// toolbox.Dump(e) // [map[address.street:未定义的属性] map[is_admin:未定义的属性] map[items.1.count:未定义的属性]]
+	message := v.default_errors["unknown"]
+	if len(e) != 3 || e[0]["address.street"] != message || e[1]["is_admin"] != message || e[2]["items.1.count"] != message {
+		fail(t, "should print 3 errors(address.street、is_admin、items.1.count), got "+fmt.Sprint(e))
+	}
+	// 非严格模式忽略未声明的属性
+	if e := v.Validate(strictRules, obj, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+}
+
+// 验证错误在前，快速失败时只返回第一个错误
+func Test_Validate_Strict_FailFast(t *testing.T) {
+	obj := M{"username": "hyb", "is_admin": true, "role": "root"}
+	e := v.Validate(strictRules, obj, "create", Strict())
+	// toolbox.Dump(e) // [map[password:不能为空] map[extra:不能为空] map[is_admin:未定义的属性] map[role:未定义的属性]]
+	if len(e) != 4 || e[0]["password"] == "" || e[3]["role"] == "" {
+		fail(t, "should print 4 errors, got "+fmt.Sprint(e))
+	}
+	obj["password"], obj["extra"] = "******", 1
+	e = v.MustCompile(strictRules).Validate(obj, "create", Strict(), FailFast())
+	if len(e) != 1 || e[0]["is_admin"] == "" {
+		fail(t, "should print error of is_admin only, got "+fmt.Sprint(e))
+	}
+}
+
+// 英文错误信息
+func Test_Validate_Strict_Lang(t *testing.T) {
+	en := New().Lang(EN_US)
+	e := en.ValidateFlat(ScenceRules{{Attr: "username", Rule: "string"}}, M{"is_admin": true}, "create", Strict())
+	if len(e) != 1 || e[0]["is_admin"] != "unknown field" {
+		fail(t, "should print error(unknown field), got "+fmt.Sprint(e))
+	}
+}
+
+// 结构体不检查
+func Test_Validate_Strict_Struct(t *testing.T) {
+	type address struct {
+		City   string `json:"city"`
+		Street string `json:"street"`
+	}
+	type user struct {
+		Username string  `json:"username"`
+		IsAdmin  bool    `json:"is_admin"`
+		Address  address `json:"address"`
+	}
+	rules := Rules{"create": {{Attr: "username", Rule: "string"}, {Attr: "address.city", Rule: "string"}}}
+	u := &user{Username: "hyb", IsAdmin: true, Address: address{City: "hz", Street: "x"}}
+	if e := v.ValidateStruct(rules, u, "create", Strict()); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	if e := v.MustCompile(rules).ValidateStruct(u, "create", Strict()); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	// M 中嵌套的结构体
+	e := v.Validate(rules, M{"username": "hyb", "is_admin": true, "address": u.Address}, "create", Strict())
+	// toolbox.Dump(e) // [map[is_admin:未定义的属性]]
+	if len(e) != 1 || e[0]["is_admin"] != v.default_errors["unknown"] {
+		fail(t, "should print error of is_admin only, got "+fmt.Sprint(e))
+	}
+}
+
+/***** SafeAttributes() *****/
+
+// 仅保留场景中声明的属性，返回副本
+func Test_SafeAttributes(t *testing.T) {
+	obj := M{
+		"username": "hyb",
+		"is_admin": true,
+		"address":  map[string]interface{}{"city": "hz", "street": "x"},
+		"items":    []interface{}{M{"sku": "a", "price": 1}},
+		"tags":     []M{{"name": "a", "color": "red"}},
+		"extra":    M{"anything": 1},
+	}
+	want := M{
+		"username": "hyb",
+		"address":  M{"city": "hz"},
+		"items":    []interface{}{M{"sku": "a"}},
+		"tags":     []interface{}{M{"name": "a"}},
+		"extra":    M{"anything": 1},
+	}
+	safe := v.SafeAttributes(strictRules, obj, "create")
+	// toolbox.Dump(safe)
+	if !reflect.DeepEqual(safe, want) {
+		fail(t, "should return "+fmt.Sprint(want)+", got "+fmt.Sprint(safe))
+	}
+	if _, ok := obj["is_admin"]; !ok {
+		fail(t, "should not modify obj")
+	}
+	if safe := v.MustCompile(strictRules).SafeAttributes(obj, "create"); !reflect.DeepEqual(safe, want) {
+		fail(t, "should return "+fmt.Sprint(want)+", got "+fmt.Sprint(safe))
+	}
+}
@@ -0,0 +1,226 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// field 结构体字段信息
+type field struct {
+	// 属性名，json 标签名，未定义时为字段名
+	name string
+	// 字段索引，嵌入结构体的字段为多级索引
+	index []int
+}
+
+// structFields 结构体字段信息缓存，reflect.Type => []field
+var structFields sync.Map
+
+// ValidateStruct 场景验证，直接验证结构体（或结构体指针）ptr，无需手动转换为 M
+// 仅被验证属性所在的结构体（包括顶层结构体）按需转换为 M（一层）交给验证器，嵌套属性路径经过的结构体、数组按字段索引直接读取，可寻址的结构体（如结构体切片的元素）在一次验证中只转换一次
+// 属性名为字段的 json 标签名（未定义时为字段名），json:"-" 及未导出的字段将被忽略
+// 支持指针（nil 视为无值）、嵌入结构体（字段提升为同级属性）、嵌套结构体及切片（可使用嵌套属性路径、objectValidator、eachValidator）
+// 忽略 Strict 选项（结构体不存在未声明的属性）
+// ptr 不是结构体或结构体指针将 panic
+func (this *validator) ValidateStruct(rules Rules, ptr interface{}, scence Scence, opts ...Option) []E {
+	return this.Validate(rules, mustStruct(ptr), scence, append(opts[:len(opts):len(opts)], structOnly)...)
+}
+
+// ValidateStruct 场景验证，同 validator.ValidateStruct
+func (this *Schema) ValidateStruct(ptr interface{}, scence Scence, opts ...Option) []E {
+	return this.Validate(mustStruct(ptr), scence, append(opts[:len(opts):len(opts)], structOnly)...)
+}
+
+// mustStruct 将结构体（或结构体指针）转换为 M，仅转换一层，嵌套结构体在验证时按需读取（见 child）
+func mustStruct(ptr interface{}) M {
+	v := reflect.ValueOf(ptr)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		panic(&RuleError{Err: ErrInvalidObject, Msg: fmt.Sprintf("%T should be a struct or a pointer to struct", ptr)})
+	}
+	return structToM(v)
+}
+
+// structToM 将结构体转换为 M，值为 nil 的指针字段不会出现在 M 中
+func structToM(v reflect.Value) M {
+	fields := fieldsOf(v.Type())
+	obj := make(M, len(fields))
+	for _, f := range fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue
+		}
+		if value, ok := normalize(fv); ok {
+			obj[f.name] = value
+		}
+	}
+	return obj
+}
+
+// structField 按属性名读取结构体字段，使用 fieldsOf 缓存的字段索引，不转换为 M，值为 nil 的指针字段视为不存在
+func structField(v reflect.Value, name string) (interface{}, bool) {
+	for _, f := range fieldsOf(v.Type()) {
+		if f.name != name {
+			continue
+		}
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			return nil, false
+		}
+		return reference(fv)
+	}
+	return nil, false
+}
+
+// structKeys 结构体的所有属性名（已排序），同 structToM 的键
+func structKeys(v reflect.Value) []string {
+	fields := fieldsOf(v.Type())
+	ks := make([]string, 0, len(fields))
+	for _, f := range fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue
+		}
+		for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+			if fv.IsNil() {
+				break
+			}
+			fv = fv.Elem()
+		}
+		if (fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface) && fv.IsNil() {
+			continue
+		}
+		ks = append(ks, f.name)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// reference 同 normalize，可寻址的结构体返回其指针，避免复制结构体
+func reference(v reflect.Value) (interface{}, bool) {
+	if v.Kind() == reflect.Struct && v.CanAddr() {
+		return v.Addr().Interface(), true
+	}
+	return normalize(v)
+}
+
+// fieldByIndex 按多级索引取字段，嵌入的结构体指针为 nil 时返回 false
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// fieldsOf 结构体字段信息，同名属性浅层字段优先
+func fieldsOf(t reflect.Type) []field {
+	if fields, ok := structFields.Load(t); ok {
+		return fields.([]field)
+	}
+	fields := collectFields(t, map[reflect.Type]bool{})
+	structFields.Store(t, fields)
+	return fields
+}
+
+// collectFields 解析结构体字段信息，visiting 为正在解析的结构体类型
+// 嵌入正在解析的类型（如 type Node struct{ *Node }）时跳过，其字段已在外层出现，避免无限递归
+func collectFields(t reflect.Type, visiting map[reflect.Type]bool) []field {
+	visiting[t] = true
+	defer delete(visiting, t)
+	fields := make([]field, 0, t.NumField())
+	names := make(map[string]bool, t.NumField())
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		// 未定义标签名的嵌入结构体，字段提升为同级属性
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, sf)
+				continue
+			}
+		}
+		if sf.PkgPath != "" { // 未导出
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{name: name, index: []int{i}})
+		names[name] = true
+	}
+	for _, sf := range embedded {
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if visiting[ft] {
+			continue
+		}
+		for _, f := range collectFields(ft, visiting) {
+			if names[f.name] {
+				continue
+			}
+			fields = append(fields, field{name: f.name, index: append([]int{sf.Index[0]}, f.index...)})
+			names[f.name] = true
+		}
+	}
+	return fields
+}
+
+// normalize 将反射值转换为验证器支持的类型
+// 解引用指针（nil 视为无值），自定义基础类型（如 type Status string）转换为对应的内置类型，无符号整数转换为 int64
+func normalize(v reflect.Value) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Bool:
+		return v.Bool(), true
+	case reflect.Int:
+		return int(v.Int()), true
+	case reflect.Int8:
+		return int8(v.Int()), true
+	case reflect.Int16:
+		return int16(v.Int()), true
+	case reflect.Int32:
+		return int32(v.Int()), true
+	case reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+		return float64(v.Uint()), true
+	case reflect.Float32:
+		return float32(v.Float()), true
+	case reflect.Float64:
+		return v.Float(), true
+	}
+	return v.Interface(), true
+}
@@ -0,0 +1,212 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type Status string
+
+type Base struct {
+	Id      uint64 `json:"id"`
+	Created string `json:"created"`
+}
+
+type Address struct {
+	City    string  `json:"city"`
+	Zipcode *string `json:"zipcode"`
+}
+
+type Item struct {
+	Sku   string `json:"sku"`
+	Count int    `json:"count"`
+}
+
+type User struct {
+	Base
+	Username string   `json:"username"`
+	Password string   `json:"-"`
+	Nickname *string  `json:"nickname,omitempty"`
+	Status   Status   `json:"status"`
+	Age      int8     `json:"age"`
+	Weight   float32  `json:"weight"`
+	Admin    bool     `json:"admin"`
+	Address  Address  `json:"address"`
+	Office   *Address `json:"office"`
+	Items    []Item   `json:"items"`
+	Tags     []string `json:"tags"`
+	Remark   string
+	secret   string
+}
+
+func newUser() *User {
+	zipcode := "333000"
+	return &User{
+		Base:     Base{Id: 1, Created: "2019-01-01"},
+		Username: "hyb",
+		Status:   "active",
+		Age:      18,
+		Weight:   53.5,
+		Address:  Address{City: "shanghai", Zipcode: &zipcode},
+		Items:    []Item{{Sku: "A001", Count: 1}, {Sku: "A002", Count: 2}},
+		Tags:     []string{"go", "php"},
+		Remark:   "remark",
+		secret:   "secret",
+	}
+}
+
+/***** ValidateStruct() *****/
+
+// 验证通过，字段类型转换为验证器支持的类型
+func Test_ValidateStruct_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: []string{"id", "username", "status", "age", "address", "Remark"}, Rule: "required"},
+			{Attr: "id", Rule: "int", Symbol: 1},
+			{Attr: "status", Rule: "in", Enum: []string{"active", "inactive"}},
+			{Attr: "age", Rule: "int", Min: 18},
+			{Attr: "weight", Rule: "number", Max: 100},
+			{Attr: "admin", Rule: "bool"},
+			{Attr: "address", Rule: "object", Rules: ScenceRules{
+				{Attr: "city", Rule: "string", Required: true},
+				{Attr: "zipcode", Rule: "zipcode", Required: true},
+			}},
+			{Attr: "items", Rule: "each", Rules: ScenceRules{
+				{Attr: "sku", Rule: "string", Required: true},
+				{Attr: "count", Rule: "int", Symbol: 1},
+			}},
+			{Attr: "tags.*", Rule: "string", Max: 10},
+		},
+	}
+	e := v.ValidateStruct(rules, newUser(), "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+}
+
+// 验证失败
+func Test_ValidateStruct(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: []string{"nickname", "office", "Password", "secret"}, Rule: "required"},
+			{Attr: "age", Rule: "int", Min: 20},
+			{Attr: "address.city", Rule: "string", Max: 3},
+			{Attr: "items.*.count", Rule: "int", Min: 2},
+		},
+	}
+	want := fmt.Sprint([]E{
+		{"nickname": v.default_errors["required"]},
+		{"office": v.default_errors["required"]},
+		{"Password": v.default_errors["required"]},
+		{"secret": v.default_errors["required"]},
+		{"age": generator(v.default_errors["integerMin"], "age", 20)},
+		{"address.city": generator(v.default_errors["stringLengthMax"], "address.city", 3)},
+		{"items.0.count": generator(v.default_errors["integerMin"], "items.0.count", 2)},
+	})
+	e := v.ValidateStruct(rules, newUser(), "create")
+	// toolbox.Dump(e)
+	if fmt.Sprint(e) != want {
+		fail(t, "should print "+want+", got "+fmt.Sprint(e))
+	}
+}
+
+// 非指针结构体、预编译
+func Test_Schema_ValidateStruct(t *testing.T) {
+	schema := v.MustCompile(Rules{
+		"update": {
+			{Attr: "id", Rule: "required"},
+			{Attr: "office", Rule: "object", Rules: ScenceRules{{Attr: "city", Rule: "required"}}},
+		},
+	})
+	user := *newUser()
+	user.Id = 0
+	user.Office = &Address{}
+	e := schema.ValidateStruct(user, "update")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+}
+
+// 参数类型错误
+func Test_ValidateStruct_TypeErr(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		// toolbox.Dump(err) // validator.M should be a struct or a pointer to struct
+		if !errors.Is(err, ErrInvalidObject) {
+			fail(t, "should panic(validator.M should be a struct or a pointer to struct)")
+		}
+	}()
+	v.ValidateStruct(Rules{"create": {}}, M{}, "create")
+}
+
+// 同一结构体在一次验证中只转换一次
+func Test_ValidateStruct_View(t *testing.T) {
+	var got []M
+	record := func(attr string, rule Rule, obj M) E {
+		got = append(got, obj)
+		return nil
+	}
+	rules := Rules{"create": {{Attr: "items.*.sku", Rule: "func", Func: record}, {Attr: "items.*.count", Rule: "func", Func: record}}}
+	v.ValidateStruct(rules, newUser(), "create")
+	if len(got) != 4 || reflect.ValueOf(got[0]).Pointer() != reflect.ValueOf(got[2]).Pointer() || reflect.ValueOf(got[0]).Pointer() == reflect.ValueOf(got[1]).Pointer() {
+		fail(t, "should reuse M of each item, got "+fmt.Sprint(got))
+	}
+}
+
+// 嵌入自身的结构体
+func Test_ValidateStruct_SelfEmbedded(t *testing.T) {
+	type Node struct {
+		*Node
+		Name string `json:"name" validate:"create:string,min=3"`
+	}
+	rules, err := StructRules(&Node{})
+	if err != nil || len(rules["create"]) != 1 {
+		fail(t, "should return rule of name, got "+fmt.Sprint(rules, err))
+	}
+	e := v.ValidateStruct(rules, &Node{Node: &Node{Name: "parent"}, Name: "a"}, "create")
+	// toolbox.Dump(e) // [map[name:长度不能小于 3]]
+	if len(e) != 1 || e[0]["name"] == "" {
+		fail(t, "should print error of name, got "+fmt.Sprint(e))
+	}
+}
+
+/***** Benchmark *****/
+
+var benchStructRules = Rules{"create": {{Attr: "items.*.sku", Rule: "string", Max: 8}, {Attr: "items.*.count", Rule: "int", Min: 0}}}
+
+func benchItems() []Item {
+	items := make([]Item, 50)
+	for i := range items {
+		items[i] = Item{Sku: "A001", Count: i}
+	}
+	return items
+}
+
+func Benchmark_ValidateStruct(b *testing.B) {
+	user := &User{Items: benchItems()}
+	schema := v.MustCompile(benchStructRules)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		schema.ValidateStruct(user, "create")
+	}
+}
+
+// 调用方将结构体转换为 M 后验证，包括转换的开销
+func Benchmark_ValidateStruct_M(b *testing.B) {
+	user := &User{Items: benchItems()}
+	schema := v.MustCompile(benchStructRules)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		items := make([]interface{}, 0, len(user.Items))
+		for _, item := range user.Items {
+			items = append(items, M{"sku": item.Sku, "count": item.Count})
+		}
+		schema.Validate(M{"username": user.Username, "items": items}, "create")
+	}
+}
@@ -0,0 +1,225 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 结构体标签名
+// 格式：`validate:"场景1,场景2:规则1,参数1=值1,参数2;规则2|场景3:规则3"`
+// 如：`validate:"create,update:int,min=18;required|read:int,symbol=1"`
+// 参数：required、bail、min、max、symbol、enum（多个值以空格分隔）、other（多个值以空格分隔）、pattern、layout、timezone、before、after、schemes、require_host、hosts、block_hosts、ports（多个值以空格分隔）、public、networks（多个值以空格分隔）、classes（多个值以空格分隔）、default、message、label、empty（absent、nil、blank、collection）、nullable（allow、forbid、absent），值包含 , ; | 时使用单引号包裹，如 pattern='^\\d{1,3}$'（标签值中的反斜杠需转义）
+// 规则为 object、each 且字段（或切片元素）为结构体时，Rule.Rules 为该结构体同一场景的标签规则
+const TAG_NAME = "validate"
+
+// tagRules 标签规则缓存，reflect.Type => Rules
+var tagRules sync.Map
+
+// StructRules 根据结构体（或结构体指针）字段的 validate 标签生成验证规则集，属性名同 ValidateStruct
+// 标签格式错误返回 *RuleError
+func StructRules(ptr interface{}) (rules Rules, err error) {
+	defer recoverRuleError(&err)
+	t := reflect.TypeOf(ptr)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(&RuleError{Err: ErrInvalidObject, Msg: fmt.Sprintf("%T should be a struct or a pointer to struct", ptr)})
+	}
+	// 返回副本，避免调用方修改缓存
+	rules = Rules{}
+	for scence, scenceRules := range rulesOf(t, map[reflect.Type]bool{}) {
+		rules[scence] = append(ScenceRules(nil), scenceRules...)
+	}
+	return rules, nil
+}
+
+// MustStructRules 同 StructRules，标签格式错误将 panic
+func MustStructRules(ptr interface{}) Rules {
+	rules, err := StructRules(ptr)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// rulesOf 结构体类型的标签规则，visiting 用于检测递归嵌套的结构体
+func rulesOf(t reflect.Type, visiting map[reflect.Type]bool) Rules {
+	if rules, ok := tagRules.Load(t); ok {
+		return rules.(Rules)
+	}
+	if visiting[t] {
+		panic(&RuleError{Err: ErrInvalidRule, Msg: t.String() + " tag '" + TAG_NAME + "' recursive struct"})
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	rules := Rules{}
+	for _, f := range fieldsOf(t) {
+		sf := t.FieldByIndex(f.index)
+		tag, ok := sf.Tag.Lookup(TAG_NAME)
+		if !ok || tag == "" {
+			continue
+		}
+		for _, group := range split(tag, '|') {
+			i := strings.Index(group, ":")
+			if i <= 0 {
+				panic(tagError(t, sf, "should be 'scence:rule'"))
+			}
+			for _, r := range split(group[i+1:], ';') {
+				rule := parseTagRule(t, sf, f.name, r)
+				for _, scence := range strings.Split(group[:i], ",") {
+					scence = strings.TrimSpace(scence)
+					rules[Scence(scence)] = append(rules[Scence(scence)], nested(rule, sf.Type, Scence(scence), visiting))
+				}
+			}
+		}
+	}
+	tagRules.Store(t, rules)
+	return rules
+}
+
+// parseTagRule 解析单条标签规则，如 int,min=18,required
+func parseTagRule(t reflect.Type, sf reflect.StructField, attr string, s string) Rule {
+	parts := split(s, ',')
+	rule := Rule{Attr: attr, Rule: strings.TrimSpace(parts[0])}
+	if rule.Rule == "" {
+		panic(tagError(t, sf, "rule not found"))
+	}
+	for _, param := range parts[1:] {
+		key, value := param, ""
+		if i := strings.Index(param, "="); i >= 0 {
+			key, value = param[:i], unquote(param[i+1:])
+		}
+		switch strings.TrimSpace(key) {
+		case "required":
+			rule.Required = value == "" || value == "true"
+		case "bail":
+			rule.Bail = value == "" || value == "true"
+		case "min":
+			rule.Min = parseTagNumber(t, sf, key, value)
+		case "max":
+			rule.Max = parseTagNumber(t, sf, key, value)
+		case "symbol":
+			symbol, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				panic(tagError(t, sf, "param 'symbol' should be int"))
+			}
+			rule.Symbol = symbol
+		case "enum":
+			rule.Enum = strings.Fields(value)
+		case "other":
+			if others := strings.Fields(value); len(others) == 1 {
+				rule.Other = others[0]
+			} else {
+				rule.Other = others
+			}
+		case "pattern":
+			rule.Pattern = value
+		case "default":
+			rule.Default = value
+		case "message":
+			rule.Message = value
+		case "label":
+			rule.Label = value
+		case "layout":
+			rule.Layout = value
+		case "timezone":
+			rule.Timezone = value
+		case "before":
+			rule.Before = value
+		case "after":
+			rule.After = value
+		case "schemes":
+			rule.Schemes = strings.Fields(value)
+		case "require_host":
+			rule.RequireHost = value == "" || value == "true"
+		case "hosts":
+			rule.Hosts = strings.Fields(value)
+		case "block_hosts":
+			rule.BlockHosts = strings.Fields(value)
+		case "ports":
+			rule.Ports = strings.Fields(value)
+		case "public":
+			rule.Public = value == "" || value == "true"
+		case "networks":
+			rule.Networks = strings.Fields(value)
+		case "classes":
+			rule.Classes = strings.Fields(value)
+		case "empty":
+			policy, ok := emptiness[value]
+			if !ok {
+				panic(tagError(t, sf, "param 'empty' should be one of absent, nil, blank, collection"))
+			}
+			rule.Empty = policy
+		case "nullable":
+			policy, ok := nullability[value]
+			if !ok {
+				panic(tagError(t, sf, "param 'nullable' should be one of allow, forbid, absent"))
+			}
+			rule.Nullable = policy
+		default:
+			panic(tagError(t, sf, "param '"+key+"' undefined"))
+		}
+	}
+	return rule
+}
+
+// parseTagNumber 解析 min/max，整数为 int，否则为 float64
+func parseTagNumber(t reflect.Type, sf reflect.StructField, key string, value string) interface{} {
+	if i, err := strconv.Atoi(value); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	panic(tagError(t, sf, "param '"+key+"' should be int or float64"))
+}
+
+// nested 规则为 object、each 且未定义 Rule.Rules 时，使用字段（或切片元素）结构体同一场景的标签规则
+func nested(rule Rule, ft reflect.Type, scence Scence, visiting map[reflect.Type]bool) Rule {
+	if rule.Rule != "object" && rule.Rule != "each" {
+		return rule
+	}
+	for ft.Kind() == reflect.Ptr || (rule.Rule == "each" && (ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array)) {
+		ft = ft.Elem()
+	}
+	if ft.Kind() == reflect.Struct {
+		rule.Rules = rulesOf(ft, visiting)[scence]
+	}
+	return rule
+}
+
+// split 按 sep 分割，忽略单引号内的 sep
+func split(s string, sep byte) []string {
+	var parts []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote 去除单引号
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// tagError 标签格式错误
+func tagError(t reflect.Type, sf reflect.StructField, msg string) *RuleError {
+	return &RuleError{Err: ErrInvalidRule, Msg: t.String() + "." + sf.Name + " tag '" + TAG_NAME + "' " + msg}
+}
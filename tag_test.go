@@ -0,0 +1,142 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type TagAddress struct {
+	City    string `json:"city" validate:"create,update:required;string,max=16"`
+	Zipcode string `json:"zipcode" validate:"create:zipcode"`
+}
+
+type TagItem struct {
+	Sku   string `json:"sku" validate:"create:string,required"`
+	Count int    `json:"count" validate:"create:int,symbol=1"`
+}
+
+type TagUser struct {
+	Base
+	Username string      `json:"username" validate:"create:required,bail;string,min=3,max=18|update:string,min=3,max=18"`
+	Password string      `json:"password" validate:"create:regex,pattern='^[A-Z]\\w{5,}$',message='密码必须由大写字母开头'"`
+	Gender   string      `json:"gender" validate:"create,update:in,enum=male female"`
+	Age      int         `json:"age" validate:"create,update:int,min=18;required"`
+	Weight   float64     `json:"weight" validate:"create:number,min=2.5,max=300"`
+	Address  *TagAddress `json:"address" validate:"create,update:object"`
+	Items    []TagItem   `json:"items" validate:"create:each,required"`
+	Remark   string
+}
+
+type TagInvalid struct {
+	Age int `validate:"int,min=18"`
+}
+
+type TagInvalidParam struct {
+	Age int `validate:"create:int,min=eighteen"`
+}
+
+type TagRecursive struct {
+	Children []TagRecursive `validate:"create:each"`
+}
+
+/***** StructRules() *****/
+
+// 生成验证规则集
+func Test_StructRules(t *testing.T) {
+	rules, err := StructRules(&TagUser{})
+	if err != nil {
+		fail(t, "should return nil error, got "+err.Error())
+		return
+	}
+	want := Rules{
+		"create": {
+			{Attr: "username", Rule: "required", Bail: true},
+			{Attr: "username", Rule: "string", Min: 3, Max: 18},
+			{Attr: "password", Rule: "regex", Pattern: `^[A-Z]\w{5,}$`, Message: "密码必须由大写字母开头"},
+			{Attr: "gender", Rule: "in", Enum: []string{"male", "female"}},
+			{Attr: "age", Rule: "int", Min: 18},
+			{Attr: "age", Rule: "required"},
+			{Attr: "weight", Rule: "number", Min: 2.5, Max: 300},
+			{Attr: "address", Rule: "object", Rules: ScenceRules{
+				{Attr: "city", Rule: "required"},
+				{Attr: "city", Rule: "string", Max: 16},
+				{Attr: "zipcode", Rule: "zipcode"},
+			}},
+			{Attr: "items", Rule: "each", Required: true, Rules: ScenceRules{
+				{Attr: "sku", Rule: "string", Required: true},
+				{Attr: "count", Rule: "int", Symbol: 1},
+			}},
+		},
+		"update": {
+			{Attr: "username", Rule: "string", Min: 3, Max: 18},
+			{Attr: "gender", Rule: "in", Enum: []string{"male", "female"}},
+			{Attr: "age", Rule: "int", Min: 18},
+			{Attr: "age", Rule: "required"},
+			{Attr: "address", Rule: "object", Rules: ScenceRules{
+				{Attr: "city", Rule: "required"},
+				{Attr: "city", Rule: "string", Max: 16},
+			}},
+		},
+	}
+	// toolbox.Dump(rules)
+	if fmt.Sprint(rules) != fmt.Sprint(want) {
+		fail(t, "should return "+fmt.Sprint(want)+", got "+fmt.Sprint(rules))
+	}
+}
+
+// 使用标签规则验证结构体
+func Test_StructRules_ValidateStruct(t *testing.T) {
+	user := &TagUser{
+		Username: "hyb",
+		Password: "abc123",
+		Gender:   "male",
+		Age:      17,
+		Weight:   53.5,
+		Address:  &TagAddress{City: "shanghai pudong district", Zipcode: "333000"},
+		Items:    []TagItem{{Sku: "A001", Count: 1}, {Count: 0}},
+	}
+	want := fmt.Sprint([]E{
+		{"password": "密码必须由大写字母开头"},
+		{"age": generator(v.default_errors["integerMin"], "age", 18)},
+		{"address.city": generator(v.default_errors["stringLengthMax"], "address.city", 16)},
+		{"items.1.count": v.default_errors["integerPositive"]},
+	})
+	e := v.ValidateStruct(MustStructRules(user), user, "create")
+	// toolbox.Dump(e)
+	if fmt.Sprint(e) != want {
+		fail(t, "should print "+want+", got "+fmt.Sprint(e))
+	}
+}
+
+// 修改返回的规则集不影响缓存
+func Test_StructRules_Copy(t *testing.T) {
+	rules := MustStructRules(TagUser{})
+	rules["create"] = rules["create"][:1]
+	rules["read"] = ScenceRules{{Attr: "id", Rule: "required"}}
+	again := MustStructRules(TagUser{})
+	if len(again["create"]) != 9 || again["read"] != nil {
+		fail(t, "should not modify cached rules")
+	}
+}
+
+// 标签格式错误
+func Test_StructRules_TagErr(t *testing.T) {
+	cases := []struct {
+		name string
+		ptr  interface{}
+		err  error
+	}{
+		{"notfound scence", TagInvalid{}, ErrInvalidRule},
+		{"invalid param", &TagInvalidParam{}, ErrInvalidRule},
+		{"recursive struct", TagRecursive{}, ErrInvalidRule},
+		{"not struct", M{}, ErrInvalidObject},
+	}
+	for _, c := range cases {
+		_, err := StructRules(c.ptr)
+		// toolbox.Dump(err)
+		if !errors.Is(err, c.err) {
+			fail(t, c.name+": should return error("+c.err.Error()+"), got "+fmt.Sprint(err))
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package validator
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// defaultPorts 协议的默认端口，URL 未指定端口时用于 Rule.Ports 检测
+var defaultPorts = map[string]int{
+	"http":  80,
+	"https": 443,
+	"ws":    80,
+	"wss":   443,
+	"ftp":   21,
+}
+
+// urlValidator URL，使用 net/url 解析，必须是包含协议的绝对 URL
+// Rule.Required       bool        可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Schemes        []string    可选    允许的协议（忽略大小写），如 http、https，默认不限制
+// Rule.RequireHost    bool        可选    必须包含主机，如 mailto:a@b.c 不包含主机
+// Rule.Hosts          []string    可选    允许的主机后缀，如 example.com 匹配 example.com 及 api.example.com，以 . 开头时只匹配子域名
+// Rule.BlockHosts     []string    可选    禁止的主机后缀，同 Rule.Hosts，优先于 Rule.Hosts
+// Rule.Ports          []string    可选    允许的端口或端口范围，如 443、8000-8999，URL 未指定端口时使用协议的默认端口
+// Rule.Public         bool        可选    禁止主机为内网、回环、链路本地、未指定地址的字面量 IP（如 127.0.0.1、10.0.0.1、[::1]、169.254.169.254），用于防止 SSRF，不解析域名
+func (this *validator) urlValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseURL)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 字符串检测
+	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
+		return this.failure("string", attr, rule)
+	}
+	// url 检测
+	u, err := url.Parse(obj[attr].(string))
+	if err != nil || u.Scheme == "" {
+		return this.failure("url", attr, rule)
+	}
+	// 协议检测
+	scheme := strings.ToLower(u.Scheme)
+	if len(p.schemes) > 0 && !contains(p.schemes, scheme) {
+		return this.failure("urlScheme", attr, rule, "enum", "["+strings.Join(p.schemes, "、")+"]")
+	}
+	// 主机检测
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		if rule.RequireHost || len(p.hosts) > 0 || len(p.ports) > 0 {
+			return this.failure("urlHost", attr, rule)
+		}
+		return nil
+	}
+	if matchHost(host, p.blockHosts) {
+		return this.failure("urlHostBlocked", attr, rule)
+	}
+	if len(p.hosts) > 0 && !matchHost(host, p.hosts) {
+		return this.failure("urlHostNotAllowed", attr, rule)
+	}
+	// 端口检测
+	if len(p.ports) > 0 {
+		port, ok := defaultPorts[scheme]
+		if s := u.Port(); s != "" {
+			port, err = strconv.Atoi(s)
+			ok = err == nil
+		}
+		if !ok || !inPorts(port, p.ports) {
+			return this.failure("urlPort", attr, rule, "enum", "["+strings.Join(rule.Ports, "、")+"]")
+		}
+	}
+	// 内网地址检测
+	if rule.Public {
+		if ip := literalIP(host); ip != nil {
+			switch ipClass(ip) {
+			case IP_PRIVATE, IP_LOOPBACK, IP_LINK_LOCAL, IP_UNSPECIFIED:
+				return this.failure("urlPrivate", attr, rule)
+			}
+		}
+	}
+	return nil
+}
+
+// matchHost 主机是否匹配后缀，后缀以 . 开头时只匹配子域名
+func matchHost(host string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasPrefix(suffix, ".") {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+		} else if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// inPorts 端口是否在范围内
+func inPorts(port int, ranges [][2]int) bool {
+	for _, r := range ranges {
+		if port >= r[0] && port <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// literalIP 主机为字面量 IP 时返回该 IP，否则返回 nil
+// 除标准格式外，还支持 inet_aton 格式（如 2130706433、0x7f.1、0177.0.0.1），这些格式会被部分客户端解析为 IPv4 地址
+func literalIP(host string) net.IP {
+	if i := strings.Index(host, "%"); i >= 0 { // IPv6 zone，如 fe80::1%eth0
+		host = host[:i]
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip
+	}
+	parts := strings.Split(host, ".")
+	if len(parts) > 4 {
+		return nil
+	}
+	var n uint64
+	for i, part := range parts {
+		v, ok := parseInetPart(part)
+		// 除最后一部分外均为 1 个字节，最后一部分占用剩余的字节
+		bits := uint(8)
+		if i == len(parts)-1 {
+			bits = 8 * uint(5-len(parts))
+		}
+		if !ok || v >= 1<<bits {
+			return nil
+		}
+		n = n<<bits | v
+	}
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+// parseInetPart 解析 inet_aton 格式的一部分，0x 开头为十六进制，0 开头为八进制，否则为十进制
+func parseInetPart(s string) (uint64, bool) {
+	base := 10
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		s, base = s[2:], 16
+	case len(s) > 1 && s[0] == '0':
+		s, base = s[1:], 8
+	}
+	v, err := strconv.ParseUint(s, base, 32)
+	return v, err == nil
+}
+
+// contains 字符串切片是否包含 s
+func contains(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// parseURL urlValidator 规则参数，Rule.Hosts、Rule.BlockHosts 不能包含空字符串，Rule.Ports 必须是有效的端口或端口范围
+func parseURL(rule Rule) *params {
+	p := &params{}
+	for _, scheme := range rule.Schemes {
+		if scheme == "" {
+			panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Schemes' should not contain empty string"))
+		}
+		p.schemes = append(p.schemes, strings.ToLower(scheme))
+	}
+	p.hosts = parseHosts(rule, "Hosts", rule.Hosts)
+	p.blockHosts = parseHosts(rule, "BlockHosts", rule.BlockHosts)
+	p.ports = parsePorts(rule)
+	return p
+}
+
+// parseHosts 解析主机后缀，统一为小写
+func parseHosts(rule Rule, name string, hosts []string) []string {
+	suffixes := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+		if host == "" || host == "." {
+			panic(newRuleError(ErrInvalidRuleParam, rule, "attribute '"+name+"' should not contain empty string"))
+		}
+		suffixes = append(suffixes, host)
+	}
+	return suffixes
+}
+
+// parsePorts 解析 Rule.Ports，无效的端口或端口范围将 panic
+func parsePorts(rule Rule) [][2]int {
+	var ports [][2]int
+	for _, s := range rule.Ports {
+		r, ok := parsePortRange(s)
+		if !ok {
+			panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Ports' should be port(0-65535) or port range like 8000-8999, got '"+s+"'"))
+		}
+		ports = append(ports, r)
+	}
+	return ports
+}
+
+// parsePortRange 解析端口（如 443）或端口范围（如 8000-8999）
+func parsePortRange(s string) ([2]int, bool) {
+	from, to := s, s
+	if i := strings.Index(s, "-"); i >= 0 {
+		from, to = s[:i], s[i+1:]
+	}
+	min, err1 := strconv.Atoi(strings.TrimSpace(from))
+	max, err2 := strconv.Atoi(strings.TrimSpace(to))
+	if err1 != nil || err2 != nil || min < 0 || max > 65535 || min > max {
+		return [2]int{}, false
+	}
+	return [2]int{min, max}, true
+}
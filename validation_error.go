@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ValidationError 验证错误，结构化的验证失败信息
+type ValidationError struct {
+	// 属性，嵌套属性为具体路径，如 items.3.sku
+	Attr string `json:"attr"`
+	// 验证规则，即验证器名，如 int
+	Rule string `json:"rule"`
+	// 错误信息的键，如 integerMin，自定义验证器为验证器名
+	Key string `json:"key"`
+	// 错误信息的参数，如 {"min": 18}，无参数时为 nil
+	Params map[string]interface{} `json:"params,omitempty"`
+	// 属性的值，无值时为 nil
+	Value interface{} `json:"value"`
+	// 错误信息
+	Message string `json:"message"`
+	// 属性的标签（Rule.Label）
+	label string
+	// 自定义错误信息（Rule.Message）
+	template string
+	// 自定义验证器返回的错误信息
+	fallback string
+	// 被比较的其他属性（Rule.Other），渲染时 {other} 替换为其标签（见 othersLabel），Params 中为属性路径
+	others []string
+	// 自定义验证器一次返回多个属性的错误时，指向其中的第一个错误，E() 将其合并为一个 E
+	group *ValidationError
+}
+
+func (this *ValidationError) Error() string {
+	return this.Attr + ": " + this.Message
+}
+
+// Errors 验证错误集合，按验证顺序排列，实现了 error
+type Errors []*ValidationError
+
+func (this Errors) Error() string {
+	msgs := make([]string, 0, len(this))
+	for _, e := range this {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Has 属性是否有验证错误
+func (this Errors) Has(attr string) bool {
+	for _, e := range this {
+		if e.Attr == attr {
+			return true
+		}
+	}
+	return false
+}
+
+// First 属性的第一个错误信息，没有验证错误时返回空字符串
+func (this Errors) First(attr string) string {
+	for _, e := range this {
+		if e.Attr == attr {
+			return e.Message
+		}
+	}
+	return ""
+}
+
+// ByAttr 按属性分组的错误信息
+func (this Errors) ByAttr() map[string][]string {
+	grouped := make(map[string][]string, len(this))
+	for _, e := range this {
+		grouped[e.Attr] = append(grouped[e.Attr], e.Message)
+	}
+	return grouped
+}
+
+// MarshalJSON 序列化为按属性分组的错误信息，如 {"age":["必须是不小于 18 的整数"]}
+func (this Errors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(this.ByAttr())
+}
+
+// E 转换为 []E，每个验证错误对应一个 E，自定义验证器一次返回的多个错误合并为一个 E，没有验证错误时返回空切片
+func (this Errors) E() []E {
+	es := make([]E, 0, len(this))
+	var groups map[*ValidationError]int
+	for _, e := range this {
+		if e.group != nil {
+			if i, ok := groups[e.group]; ok {
+				es[i][e.Attr] = e.Message
+				continue
+			}
+			if groups == nil {
+				groups = make(map[*ValidationError]int)
+			}
+			groups[e.group] = len(es)
+		}
+		es = append(es, E{e.Attr: e.Message})
+	}
+	return es
+}
+
+// err 没有验证错误时返回 nil，避免返回非 nil 的空 Errors
+func (this Errors) err() error {
+	if len(this) == 0 {
+		return nil
+	}
+	return this
+}
@@ -0,0 +1,144 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	// "github.com/goindow/toolbox"
+)
+
+var checkRules = Rules{
+	"create": {
+		{Attr: []string{"username", "password"}, Rule: "required"},
+		{Attr: "age", Rule: "int", Min: 18, Max: 60},
+		{Attr: "gender", Rule: "in", Enum: []string{"0", "1"}, Message: "性别错误"},
+		{Attr: "items", Rule: "each", Rules: ScenceRules{
+			{Attr: "sku", Rule: "string", Max: 3},
+		}},
+		{Attr: "count", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+			return E{attr: "必须是偶数", "total": "与 count 不符"}
+		}},
+	},
+}
+
+/***** Check() *****/
+
+// 验证失败返回 Errors，包含属性、规则、错误信息的键、参数、属性的值、错误信息
+func Test_Check(t *testing.T) {
+	obj := M{"password": "******", "age": "17", "gender": "male", "items": []interface{}{M{"sku": "abcd"}}, "count": 3}
+	err := v.Check(checkRules, obj, "create")
+	var errs Errors
+	if !errors.As(err, &errs) {
+		fail(t, "should return Errors, got "+fmt.Sprint(err))
+		return
+	}
+	// toolbox.Dump(errs)
+	want := Errors{
+		{Attr: "username", Rule: "required", Key: "required", Message: v.default_errors["required"]},
+		{Attr: "age", Rule: "int", Key: "integerRange", Params: map[string]interface{}{"min": 18, "max": 60}, Value: "17", Message: generator(v.default_errors["integerRange"], "age", 18, 60)},
+		{Attr: "gender", Rule: "in", Key: "in", Params: map[string]interface{}{"enum": "[0、1]"}, Value: "male", Message: "性别错误"},
+		{Attr: "items.0.sku", Rule: "string", Key: "stringLengthMax", Params: map[string]interface{}{"max": 3}, Value: "abcd", Message: generator(v.default_errors["stringLengthMax"], "items.0.sku", 3)},
+		{Attr: "count", Rule: "func", Key: "func", Value: 3, Message: "必须是偶数"},
+		{Attr: "total", Rule: "func", Key: "func", Message: "与 count 不符"},
+	}
+	if len(errs) != len(want) {
+		fail(t, "should return "+fmt.Sprint(len(want))+" errors, got "+errs.Error())
+		return
+	}
+	for i := range want {
+		// 忽略内部字段
+		e := errs[i]
+		got := ValidationError{Attr: e.Attr, Rule: e.Rule, Key: e.Key, Params: e.Params, Value: e.Value, Message: e.Message}
+		if !reflect.DeepEqual(&got, want[i]) {
+			fail(t, "should return "+fmt.Sprintf("%+v", *want[i])+", got "+fmt.Sprintf("%+v", got))
+		}
+	}
+	// 与 Validate 一致
+	if e := v.Validate(checkRules, obj, "create"); !reflect.DeepEqual(e, errs.E()) {
+		fail(t, "should equal to Validate, got "+fmt.Sprint(e))
+	}
+}
+
+// 验证通过返回 nil，规则定义错误返回 *RuleError
+func Test_Check_OK(t *testing.T) {
+	rules := Rules{"create": {{Attr: "username", Rule: "required"}}}
+	if err := v.Check(rules, M{"username": "hyb"}, "create"); err != nil {
+		fail(t, "should return nil, got "+fmt.Sprint(err))
+	}
+	if err := v.MustCompile(rules).Check(M{"username": "hyb"}, "create"); err != nil {
+		fail(t, "should return nil, got "+fmt.Sprint(err))
+	}
+	err := v.Check(rules, objEmpty, "update")
+	var ruleErr *RuleError
+	if !errors.As(err, &ruleErr) || !errors.Is(err, ErrSceneUndefined) {
+		fail(t, "should return error(scence undefined), got "+fmt.Sprint(err))
+	}
+}
+
+/***** Errors *****/
+
+// Has、First、ByAttr、Error
+func Test_Errors(t *testing.T) {
+	errs := Errors{
+		{Attr: "age", Message: "必须是整数"},
+		{Attr: "username", Message: "不能为空"},
+		{Attr: "age", Message: "必须是不小于 18 的整数"},
+	}
+	if !errs.Has("age") || errs.Has("password") {
+		fail(t, "Has should return true for age and false for password")
+	}
+	if errs.First("age") != "必须是整数" || errs.First("password") != "" {
+		fail(t, "First should return the first message of age, got "+errs.First("age"))
+	}
+	want := map[string][]string{"age": {"必须是整数", "必须是不小于 18 的整数"}, "username": {"不能为空"}}
+	if !reflect.DeepEqual(errs.ByAttr(), want) {
+		fail(t, "ByAttr should return "+fmt.Sprint(want)+", got "+fmt.Sprint(errs.ByAttr()))
+	}
+	if errs.Error() != "age: 必须是整数; username: 不能为空; age: 必须是不小于 18 的整数" {
+		fail(t, "Error should join messages, got "+errs.Error())
+	}
+}
+
+// 自定义验证器一次返回的多个错误合并为一个 E
+func Test_Errors_E_Group(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "count", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				return E{attr: "必须是偶数", "total": "与 count 不符"}
+			}},
+			{Attr: "total", Rule: "int"},
+			{Attr: "price", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				return E{"discount": "不能大于 price", attr: "必须大于 0"}
+			}},
+		},
+	}
+	e := v.Validate(rules, M{"count": 3, "total": "a", "price": 0}, "create")
+	// toolbox.Dump(e) // [map[count:必须是偶数 total:与 count 不符] map[total:必须是整数] map[discount:不能大于 price price:必须大于 0]]
+	want := []E{
+		{"count": "必须是偶数", "total": "与 count 不符"},
+		{"total": v.default_errors["integer"]},
+		{"discount": "不能大于 price", "price": "必须大于 0"},
+	}
+	if !reflect.DeepEqual(e, want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	// Errors 仍按属性展开
+	if errs := v.Check(rules, M{"count": 3, "total": "a", "price": 0}, "create").(Errors); len(errs) != 5 {
+		fail(t, "should return 5 errors, got "+errs.Error())
+	}
+}
+
+// 序列化为按属性分组的错误信息
+func Test_Errors_MarshalJSON(t *testing.T) {
+	errs := Errors{
+		{Attr: "age", Rule: "int", Value: func() {}, Message: "必须是整数"},
+		{Attr: "username", Message: "不能为空"},
+	}
+	b, err := json.Marshal(map[string]interface{}{"errors": errs})
+	// toolbox.Dump(string(b)) // {"errors":{"age":["必须是整数"],"username":["不能为空"]}}
+	if err != nil || string(b) != `{"errors":{"age":["必须是整数"],"username":["不能为空"]}}` {
+		fail(t, "should marshal to grouped messages, got "+string(b)+fmt.Sprint(err))
+	}
+}
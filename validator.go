@@ -5,14 +5,12 @@
 package validator
 
 import (
-	"errors"
 	"fmt"
-	"github.com/goindow/validator/i18n"
-	"net"
 	"reflect"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode/utf8"
 	// "github.com/goindow/toolbox"
 )
@@ -29,15 +27,22 @@ const (
 // 验证规则
 type Rule struct {
 	// 必须，待验证属性，单个属性 string，多个属性 []string，其他类型或未定义将 panic
+	// 支持嵌套属性路径，如 address.city、items.0.sku、items.*.sku，错误信息的键为具体路径，如 items.3.sku
 	Attr interface{}
 	// 必须，验证规则，即验证器，不存在的验证器或未定义将 panic
 	Rule string
-	// 可选，自定义错误信息
+	// 可选，自定义错误信息，{label} 替换为属性的标签
 	Message string
+	// 可选，属性的显示名称（标签），替换错误信息中的 {label}，优先于 SetLabels 设置的标签
+	Label string
 	// 可选，可空限制，作用于除 requiredValidator 外的所有验证器
 	// false(默认) - 有值验证/无值跳过(如果同时设置了 requiredValidator ，则报 required 错误，此时错误是由 requiredValidator 报出的)
 	// true - 有值验证/无值报 required 错误
 	Required bool
+	// 可选，空值判定策略，作用于 requiredValidator、条件必填验证器及 Rule.Required，EMPTY_DEFAULT(默认) 使用验证器的策略（见 SetEmptiness）
+	Empty Emptiness
+	// 可选，null 处理方式，属性存在且值为 nil 时，NULL_DEFAULT(默认) - 由验证器验证，NULL_ALLOW - 跳过本条规则，NULL_FORBID - 报 notNull 错误，NULL_ABSENT - 视为属性不存在
+	Nullable Nullability
 	// 可选，符号限制，作用于 numberValidator、integerValidator、decimalValidator
 	// 0(默认) - 正/负数，>0 - 正数(不包含0)，<0 - 负数(不包含0)
 	Symbol int64
@@ -48,12 +53,59 @@ type Rule struct {
 	Max interface{}
 	// 可选，最小限制，同 Rule.Max
 	Min interface{}
-	// 必选（inValidator），枚举限制，作用于 inValidator
+	// 必选（inValidator、requiredIfValidator、requiredUnlessValidator），枚举限制，作用于 inValidator
+	// 作用于 requiredIfValidator、requiredUnlessValidator 时，为 Rule.Other 的取值范围
 	Enum []string
+	// 必选（requiredIfValidator、requiredUnlessValidator、requiredWithValidator、requiredWithoutValidator、sameValidator、differentValidator、gtFieldValidator 等字段比较验证器），其他属性
+	// 单个属性 string，多个属性 []string（仅 requiredWithValidator、requiredWithoutValidator），支持嵌套属性路径（相对于被验证属性所在的对象），其他类型将 panic
+	Other interface{}
 	// 必选（regexValidator），正则匹配模式，作用于 regexValidator
 	Pattern string
+	// 可选，日期/时间格式（Go layout），单个格式 string，多个格式 []string（依次尝试），作用于 dateValidator、datetimeValidator、timeValidator
+	// 默认 dateValidator 为 LAYOUT_DATE，datetimeValidator 为 LAYOUT_DATETIME（RFC 3339），timeValidator 为 LAYOUT_TIME，其他类型将 panic
+	Layout interface{}
+	// 可选，时区（IANA 时区名，如 Asia/Shanghai），用于解析不含时区的日期/时间字符串及计算相对时间，默认为 UTC，无效的时区将 panic
+	Timezone string
+	// 可选，必须早于（不包含），作用于 dateValidator、datetimeValidator、timeValidator
+	// time.Time 或字符串，字符串为 Rule.Layout 格式的绝对时间，或相对于验证时的时间，如 now、today、tomorrow、yesterday、now+7d、today-1mo，其他将 panic
+	Before interface{}
+	// 可选，必须晚于（不包含），同 Rule.Before，均为绝对时间时 Rule.After 不早于 Rule.Before 将 panic
+	After interface{}
+	// 可选，允许的协议（忽略大小写），作用于 urlValidator，默认不限制
+	Schemes []string
+	// 可选，必须包含主机，作用于 urlValidator
+	RequireHost bool
+	// 可选，允许的主机后缀，如 example.com 匹配 example.com 及其子域名，以 . 开头时只匹配子域名，作用于 urlValidator
+	Hosts []string
+	// 可选，禁止的主机后缀，同 Rule.Hosts，优先于 Rule.Hosts
+	BlockHosts []string
+	// 可选，允许的端口或端口范围，如 443、8000-8999，作用于 urlValidator、portValidator、hostPortValidator，无效的端口将 panic
+	Ports []string
+	// 可选，只允许公网地址，作用于 urlValidator，主机为内网、回环、链路本地、未指定地址的字面量 IP 时验证失败（防止 SSRF）
+	Public bool
+	// 可选，允许的网段（CIDR），作用于 ipValidator、ipv4Validator、ipv6Validator，无效的 CIDR 将 panic
+	Networks []string
+	// 可选，允许的地址分类（IP_PUBLIC、IP_PRIVATE、IP_LOOPBACK、IP_MULTICAST、IP_LINK_LOCAL、IP_UNSPECIFIED），作用于 ipValidator、ipv4Validator、ipv6Validator，其他将 panic
+	Classes []string
 	// 必选（fucValidator），自定义验证函数，作用于 funcValidator
 	Func F
+	// 必选（default 过滤器），默认值，属性无值（不存在或为 nil）时使用
+	Default interface{}
+	// 必选（objectValidator、eachValidator），嵌套验证规则集，作用于 objectValidator、eachValidator
+	// objectValidator 使用 Rules 验证对象的属性，eachValidator 使用 Rules 验证数组的每个元素（元素必须是对象）
+	// 嵌套验证的错误信息的键以父属性路径为前缀，如 address.city、items.3.sku
+	Rules ScenceRules
+	// 可选，条件限制，作用于所有验证器，返回 false 时对该属性跳过本条规则，参数为被验证属性所在的对象（如 address.city 为 address，items.*.sku 为每个元素）
+	// 每个被验证属性（嵌套属性路径为每个具体路径）调用一次
+	When func(M) bool
+	// 可选，验证失败（包括嵌套验证失败）后，同一场景中该属性的后续规则不再验证，作用于所有验证器
+	Bail bool
+	// 可选，适用场景，未定义时适用于所有场景，作用于所有验证器，常用于扁平规则列表（见 ValidateFlat）
+	On []Scence
+	// 可选，排除场景，优先于 Rule.On
+	Except []Scence
+	// 预解析的规则参数，由 Compile 生成
+	params *params
 }
 
 // 场景
@@ -73,147 +125,439 @@ type E map[string]string
 // 验证器函数类型
 type F func(string, Rule, M) E
 
+// validatorFunc 内置验证器函数类型，验证失败返回结构化的验证错误，自定义验证器（F）由 custom 转换
+type validatorFunc func(string, Rule, M) Errors
+
+// validator 验证器，配置完成后（Lang、AddValidator）可在多个 goroutine 中共享使用
+// 每次 Validate 的错误信息都是独立收集的，互不干扰
 type validator struct {
+	// 读写锁，保护 lang、default_errors、labels、messages、emptiness、validators
+	mu sync.RWMutex
 	// 默认语言
 	lang string
 	// 默认错误
 	default_errors map[string]string
+	// 属性的标签，语言 => 属性名或属性路径 => 标签
+	labels map[string]map[string]string
+	// 覆盖的错误信息，语言 => 错误信息的键 => 错误信息，见 SetMessages
+	messages map[string]map[string]string
+	// 空值判定策略
+	emptiness Emptiness
 	// 验证器
-	validators map[string]F
-	// 错误收信息
-	errors []E
+	validators map[string]validatorFunc
 }
 
 // New 构造器，validator.New()
 func New() *validator {
+	catalogue, _ := catalogueOf(DEFAULT_LANG)
 	this := &validator{
 		lang:           DEFAULT_LANG,
-		default_errors: i18n.Errors[DEFAULT_LANG],
+		default_errors: catalogue,
+		emptiness:      EMPTY_ABSENT,
 	}
 	// 挂载内置验证器
 	this.mount()
 	return this
 }
 
-// Lang 设置默认的错误信息语言
+// Lang 设置默认的错误信息语言，支持内置语言及 RegisterLang 注册的语言，不区分大小写
 func (this *validator) Lang(lang string) *validator {
 	l := strings.ToUpper(lang)
-	if _, ok := i18n.Errors[l]; !ok {
-		panic(lang + " unsupport language")
+	catalogue, ok := catalogueOf(l)
+	if !ok {
+		panic(&RuleError{Err: ErrUnsupportedLang, Msg: lang + " unsupport language"})
 	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
 	this.lang = l
-	this.default_errors = i18n.Errors[l]
+	this.default_errors = catalogue
 	return this
 }
 
 // AddValidator 自定义验证器
 func (this *validator) AddValidator(name string, customValidator F) {
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if _, ok := filters[name]; ok {
+		panic(&RuleError{Err: ErrValidatorExists, Msg: "filter named '" + name + "' already exists"})
+	}
 	if _, ok := this.validators[name]; ok {
-		panic(errors.New("validator named '" + name + "' already exists"))
+		panic(&RuleError{Err: ErrValidatorExists, Msg: "validator named '" + name + "' already exists"})
 	}
-	this.validators[name] = customValidator
+	this.validators[name] = custom(customValidator)
 }
 
-// Validate 场景验证
-func (this *validator) Validate(rules Rules, obj M, scence Scence) []E {
-	// 初始化 errors
-	this.errors = make([]E, 0)
-	// 场景不存在
-	scenceRules, ok := rules[scence]
-	if !ok {
-		panic(fmt.Sprint(scence) + " scence undefined")
-	}
+// Validate 场景验证，并发安全，错误信息由每次调用单独收集
+// 场景的规则包括默认场景（SCENCE_DEFAULT）的规则及继承（RULE_EXTENDS）的规则
+// 每次调用都会重新检查规则定义，规则固定时可使用 Compile 预编译
+// opts 为本次验证的选项，如 FailFast()
+func (this *validator) Validate(rules Rules, obj M, scence Scence, opts ...Option) []E {
+	// 场景不存在、场景继承
+	scenceRules := scenceRulesOf(rules, scence)
 	// 验证
+	_, errs := this.exec(this.compile(scenceRules), obj, newState(scence, opts))
+	return errs.E()
+}
+
+// ValidateE 场景验证，同 Validate，但规则定义错误（场景不存在、验证器不存在、参数错误等）不会 panic，而是以 error 返回
+// 返回的 error 为 *RuleError，可使用 errors.Is 与 ErrSceneUndefined、ErrValidatorUndefined、ErrInvalidRule、ErrInvalidRuleParam 等比较
+func (this *validator) ValidateE(rules Rules, obj M, scence Scence, opts ...Option) (errs []E, err error) {
+	defer recoverRuleError(&err)
+	return this.Validate(rules, obj, scence, opts...), nil
+}
+
+// ValidateFlat 场景验证，验证扁平规则列表，每条规则适用的场景由 Rule.On、Rule.Except 决定，任意场景均可验证
+// 规则定义错误将 panic，opts 同 Validate
+func (this *validator) ValidateFlat(rules ScenceRules, obj M, scence Scence, opts ...Option) []E {
+	_, errs := this.exec(this.compile(rules), obj, newState(scence, opts))
+	return errs.E()
+}
+
+// ValidateFlatE 场景验证，同 ValidateFlat，规则定义错误以 error 返回
+func (this *validator) ValidateFlatE(rules ScenceRules, obj M, scence Scence, opts ...Option) (errs []E, err error) {
+	defer recoverRuleError(&err)
+	return this.ValidateFlat(rules, obj, scence, opts...), nil
+}
+
+// Check 场景验证，同 Validate，验证失败返回 Errors（结构化的验证错误），规则定义错误返回 *RuleError，验证通过返回 nil
+// 可使用 errors.As 区分，如 var errs validator.Errors; errors.As(err, &errs)
+func (this *validator) Check(rules Rules, obj M, scence Scence, opts ...Option) (err error) {
+	defer recoverRuleError(&err)
+	_, errs := this.exec(this.compile(scenceRulesOf(rules, scence)), obj, newState(scence, opts))
+	return errs.err()
+}
+
+// compile 编译单个场景的验证规则集
+func (this *validator) compile(scenceRules ScenceRules) []compiledRule {
+	compiled := make([]compiledRule, 0, len(scenceRules))
 	for _, rule := range scenceRules {
-		this.dispatch(rule, obj)
+		compiled = append(compiled, this.dispatch(rule))
 	}
-	return this.errors
+	return compiled
 }
 
-// dispatch 验证调度器
-func (this *validator) dispatch(rule Rule, obj M) {
+// exec 执行已编译的场景验证规则集，严格模式下检查未声明的属性
+// 有过滤器或需返回过滤后的副本时，在 obj 的副本（浅复制，过滤器写入时再复制经过的对象、数组，见 writable）上执行，返回该副本
+func (this *validator) exec(compiled []compiledRule, obj M, st *state) (M, Errors) {
+	if st.clean || filtered(compiled) {
+		o, _ := st.own(obj)
+		obj = o.(M)
+	}
+	errs := this.run(compiled, obj, st)
+	if st.strict {
+		errs = this.strict(errs, compiled, obj, st)
+	}
+	this.render(errs, st.lang)
+	return obj, errs
+}
+
+// run 执行已编译的验证规则集
+func (this *validator) run(compiled []compiledRule, obj M, st *state) Errors {
+	// 初始化 errors
+	errs := make(Errors, 0)
+	// 验证失败且设置了 Rule.Bail 的属性路径
+	bailed := make(map[string]bool)
+	for _, c := range compiled {
+		if st.stopped {
+			break
+		}
+		errs = this.adapter(errs, c, obj, st, bailed)
+	}
+	return errs
+}
+
+// dispatch 验证调度器，检查规则定义，查找过滤器或验证器，解析内置验证器的规则参数
+func (this *validator) dispatch(rule Rule) compiledRule {
 	name := rule.Rule
 	// Rule.Rule 未定义
 	if name == "" {
-		panic(errors.New(fmt.Sprint(rule) + " attribute 'Rule' not found"))
+		panic(newRuleError(ErrInvalidRule, rule, "attribute 'Rule' not found"))
 	}
-	// 验证器不存在
+	// 过滤器、验证器不存在
+	fn := filters[name]
+	this.mu.RLock()
 	f, ok := this.validators[name]
-	if !ok {
-		panic(errors.New(name + " validator undefined"))
+	this.mu.RUnlock()
+	if !ok && fn == nil {
+		panic(&RuleError{Err: ErrValidatorUndefined, Rule: rule, Msg: name + " validator undefined"})
 	}
 	// Rule.Attr 类型错误
-	attr := rule.Attr
-	switch attr.(type) {
+	var attrs []attribute
+	switch attr := rule.Attr.(type) {
 	case string:
-		this.adapter(f, rule, obj, false)
+		attrs = []attribute{newAttribute(attr)}
 	case []string:
-		this.adapter(f, rule, obj, true)
+		attrs = make([]attribute, 0, len(attr))
+		for _, a := range attr {
+			attrs = append(attrs, newAttribute(a))
+		}
 	case nil:
-		panic(errors.New(fmt.Sprint(rule) + " attribute 'Attr' not found"))
+		panic(newRuleError(ErrInvalidRule, rule, "attribute 'Attr' not found"))
 	default:
-		panic("attribute 'Attr' should be 'string' or '[]string'")
+		panic(&RuleError{Err: ErrInvalidRule, Rule: rule, Msg: "attribute 'Attr' should be 'string' or '[]string'"})
+	}
+	// 空值判定策略、null 处理方式
+	if !rule.Empty.valid() {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Empty' should be one of EMPTY_*"))
+	}
+	if !rule.Nullable.valid() {
+		panic(newRuleError(ErrInvalidRuleParam, rule, "attribute 'Nullable' should be one of NULL_*"))
+	}
+	// 规则参数
+	if parse, ok := parsers[name]; ok {
+		rule.params = parse(rule)
+	}
+	// 嵌套验证规则集
+	var children []compiledRule
+	if name == "object" || name == "each" {
+		children = this.compile(rule.Rules)
+	}
+	return compiledRule{f: f, filter: fn, rule: rule, attrs: attrs, children: children, writes: fn != nil || filtered(children)}
+}
+
+// adapter 多字段适配器，嵌套属性路径（如 address.city、items.*.sku）将被展开为具体路径逐一验证
+func (this *validator) adapter(errs Errors, c compiledRule, obj M, st *state, bailed map[string]bool) Errors {
+	// 场景限制
+	if !applicable(c.rule, st.scence) {
+		return errs
+	}
+	// 过滤器
+	if c.filter != nil {
+		this.apply(c, obj, st, bailed)
+		return errs
+	}
+	traverse(c, obj, st, bailed, func(path string, attr string, o M) {
+		errs = this.validate(errs, c, path, attr, o, st, bailed)
+	})
+	return errs
+}
+
+// traverse 展开规则的被验证属性，嵌套属性路径展开为具体路径，对每个属性调用 fn，验证器、过滤器共用
+// path 为具体路径，attr 为末级属性名，obj 为其所在的对象；快速失败已停止、该属性已中止验证（Rule.Bail）或 Rule.When 返回 false 时跳过
+func traverse(c compiledRule, obj M, st *state, bailed map[string]bool, fn func(path string, attr string, obj M)) {
+	visit := func(path string, attr string, o M) {
+		if !st.stopped && !bailed[path] && when(c.rule, o) {
+			fn(path, attr, o)
+		}
+	}
+	for _, attr := range c.attrs {
+		// 嵌套属性，obj 中存在同名属性时（如 "a.b"）仍按单个属性处理
+		if attr.segments != nil {
+			if _, ok := obj[attr.name]; !ok {
+				if c.writes {
+					st.writable(obj, writePath(c, attr.segments))
+				}
+				for _, t := range st.resolve(obj, attr.segments) {
+					visit(t.path, t.leaf, t.obj)
+				}
+				continue
+			}
+		}
+		if c.writes {
+			st.writable(obj, writePath(c, []string{attr.name}))
+		}
+		visit(attr.name, attr.name, obj)
+	}
+}
+
+// when 条件限制，Rule.When 未定义或返回 true 时验证，obj 为被验证属性所在的对象
+func when(rule Rule, obj M) bool {
+	return rule.When == nil || rule.When(obj)
+}
+
+// validate 验证，验证失败时将错误追加到 errs
+// 验证器以末级属性名 attr 验证其所在的对象 obj，错误的属性替换为具体路径 path
+func (this *validator) validate(errs Errors, c compiledRule, path string, attr string, obj M, st *state, bailed map[string]bool) Errors {
+	// null 处理
+	failed, skip := this.null(c, attr, obj)
+	if !skip {
+		failed = c.f(attr, c.rule, obj)
+	}
+	if len(failed) == 0 {
+		// 允许为 null，值为 nil 时该属性的后续规则不再验证
+		if value, ok := obj[attr]; ok && value == nil && c.rule.Rule == "nullable" {
+			bailed[path] = true
+			return errs
+		}
+		// 嵌套验证
+		if value, ok := obj[attr]; c.children != nil && !this.empty(value, ok, c.rule) {
+			n := len(errs)
+			errs = this.nest(errs, c, path, value, st)
+			if len(errs) > n && c.rule.Bail {
+				bailed[path] = true
+			}
+		}
+		return errs
+	}
+	for _, e := range failed {
+		if e.Attr == attr {
+			e.Attr = path
+			e.Value = obj[attr]
+		}
+	}
+	if c.rule.Bail {
+		bailed[path] = true
 	}
+	st.stopped = st.failFast
+	return append(errs, failed...)
 }
 
-// adapter 多字段适配器
-func (this *validator) adapter(f F, rule Rule, obj M, ismultiple bool) {
-	// 多字段
-	if ismultiple {
-		for _, attr := range rule.Attr.([]string) {
-			this.validate(f, attr, rule, obj)
+// nest 嵌套验证，objectValidator 验证对象本身，eachValidator 验证数组的每个元素，错误信息的键以 path 为前缀
+func (this *validator) nest(errs Errors, c compiledRule, path string, value interface{}, st *state) Errors {
+	if c.rule.Rule == "object" {
+		o, _ := st.object(value)
+		return append(errs, prefix(this.run(c.children, o, st), path)...)
+	}
+	for _, key := range keys(value) {
+		if st.stopped {
+			break
+		}
+		elem, _ := child(value, key)
+		o, ok := st.object(elem)
+		if !ok { // 元素不是对象
+			e := this.failure("object", join(path, key), c.rule)
+			e[0].Value = elem
+			errs = append(errs, e...)
+			st.stopped = st.failFast
+			continue
 		}
-		return
+		errs = append(errs, prefix(this.run(c.children, o, st), join(path, key))...)
 	}
-	// 单字段
-	this.validate(f, rule.Attr.(string), rule, obj)
+	return errs
 }
 
-// validate 验证
-func (this *validator) validate(f F, attr string, rule Rule, obj M) {
-	if e := f(attr, rule, obj); e != nil {
-		this.errors = append(this.errors, e)
+// applicable 规则是否适用于场景，Rule.Except 优先于 Rule.On
+func applicable(rule Rule, scence Scence) bool {
+	for _, s := range rule.Except {
+		if s == scence {
+			return false
+		}
+	}
+	if len(rule.On) == 0 {
+		return true
+	}
+	for _, s := range rule.On {
+		if s == scence {
+			return true
+		}
 	}
+	return false
 }
 
-// generator 错误信息生成器
-func (this *validator) generator(name string, attr string, rule Rule, placeholder ...interface{}) E {
-	ok := false
-	e := rule.Message
-	// 自定义错误信息
-	if e != "" {
-		return E{attr: e}
+// prefix 为错误的属性添加父属性路径前缀
+func prefix(errs Errors, path string) Errors {
+	for _, e := range errs {
+		e.Attr = join(path, e.Attr)
 	}
-	// 内置错误信息
-	e, ok = this.default_errors[name]
-	if ok {
-		// 替换标签，已删除
-		// e = strings.Replace(e, "{label}", attr, -1)
-		// 替换占位符
-		e = fmt.Sprintf(e, placeholder...)
-	} else { // 内置错误信息不存在
-		e = "unknow error"
+	return errs
+}
+
+// custom 将自定义验证器（F）转换为内置验证器函数类型
+func custom(f F) validatorFunc {
+	return func(attr string, rule Rule, obj M) Errors {
+		return fromE(f(attr, rule, obj), attr, rule)
 	}
-	return E{attr: e}
+}
+
+// fromE 将自定义验证器返回的 E 转换为 Errors，按属性排序，错误信息的键为验证器名，Rule.Label、Rule.Message 仅作用于被验证属性 attr
+// 返回的错误信息可被 SetMessages 覆盖，为空字符串时使用 SetMessages 设置的错误信息
+func fromE(e E, attr string, rule Rule) Errors {
+	if len(e) == 0 {
+		return nil
+	}
+	attrs := make([]string, 0, len(e))
+	for attr := range e {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	errs := make(Errors, 0, len(e))
+	for _, a := range attrs {
+		ve := &ValidationError{Attr: a, Rule: rule.Rule, Key: rule.Rule, fallback: e[a]}
+		if a == attr {
+			ve.label, ve.template = rule.Label, rule.Message
+		}
+		errs = append(errs, ve)
+	}
+	// 同一次调用返回的多个错误，Errors.E() 合并为一个 E
+	if len(errs) > 1 {
+		for _, ve := range errs {
+			ve.group = errs[0]
+		}
+	}
+	return errs
+}
+
+// failure 生成验证错误，key 为错误信息的键，params 为错误信息的参数（名称、值交替），如 "min", 18, "max", 60
+// 错误信息由 render 生成，定义了 Rule.Message 时使用自定义错误信息，否则使用当前语言的内置错误信息，参数替换同名占位符，如 {min}
+func (this *validator) failure(key string, attr string, rule Rule, params ...interface{}) Errors {
+	e := &ValidationError{Attr: attr, Rule: rule.Rule, Key: key, label: rule.Label, template: rule.Message}
+	if len(params) != 0 {
+		e.Params = make(map[string]interface{}, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			e.Params[fmt.Sprint(params[i])] = params[i+1]
+		}
+	}
+	return Errors{e}
+}
+
+// compared 同 failure，错误信息的参数 other 为被比较的其他属性 others（以 、 分隔），渲染时替换为其标签
+func (this *validator) compared(key string, attr string, rule Rule, others []string, params ...interface{}) Errors {
+	errs := this.failure(key, attr, rule, append([]interface{}{PLACEHOLDER_OTHER, strings.Join(others, "、")}, params...)...)
+	errs[0].others = others
+	return errs
+}
+
+// generator 错误信息生成器，可在自定义验证器中使用内置错误信息，params 同 failure，如 "min", 18
+func (this *validator) generator(name string, attr string, rule Rule, params ...interface{}) E {
+	e := this.failure(name, attr, rule, params...)
+	this.render(e, "")
+	return E{attr: e[0].Message}
 }
 
 // mount 挂载内置验证器
 func (this *validator) mount() {
-	this.validators = map[string]F{
+	this.validators = map[string]validatorFunc{
 		"func":     this.funcValidator, // 自定义验证函数
 		"required": this.requiredValidator,
-		"in":       this.inValidator,
-		"string":   this.stringValidator,
-		"integer":  this.integerValidator,
-		"decimal":  this.decimalValidator,
-		"number":   this.numberValidator,
-		"boolean":  this.booleanValidator,
-		"ip":       this.ipValidator,
-		"regex":    this.regexValidator,
-		"email":    this.emailValidator,
-		"tel":      this.telValidator,
-		"mobile":   this.mobileValidator,
-		"zipcode":  this.zipcodeValidator,
+		// 条件必填
+		"required_if":      this.requiredIfValidator,
+		"required_unless":  this.requiredUnlessValidator,
+		"required_with":    this.requiredWithValidator,
+		"required_without": this.requiredWithoutValidator,
+		// 字段比较
+		"same":      this.sameValidator,
+		"different": this.differentValidator,
+		"gt_field":  this.gtFieldValidator,
+		"gte_field": this.gteFieldValidator,
+		"lt_field":  this.ltFieldValidator,
+		"lte_field": this.lteFieldValidator,
+		"in":        this.inValidator,
+		"string":    this.stringValidator,
+		"integer":   this.integerValidator,
+		"decimal":   this.decimalValidator,
+		"number":    this.numberValidator,
+		"boolean":   this.booleanValidator,
+		"ip":        this.ipValidator,
+		"ipv4":      this.ipv4Validator,
+		"ipv6":      this.ipv6Validator,
+		"cidr":      this.cidrValidator,
+		"mac":       this.macValidator,
+		"hostname":  this.hostnameValidator,
+		"port":      this.portValidator,
+		"host_port": this.hostPortValidator,
+		"regex":     this.regexValidator,
+		"date":      this.dateValidator,
+		"datetime":  this.datetimeValidator,
+		"time":      this.timeValidator,
+		"url":       this.urlValidator,
+		"email":     this.emailValidator,
+		"tel":       this.telValidator,
+		"mobile":    this.mobileValidator,
+		"zipcode":   this.zipcodeValidator,
+		"object":    this.objectValidator,
+		"each":      this.eachValidator,
+		"nullable":  this.nullableValidator,
+		"not_null":  this.notNullValidator,
 		// 别名
 		"int":   this.integerValidator, // integer
 		"float": this.decimalValidator, // decimal
@@ -224,48 +568,117 @@ func (this *validator) mount() {
 // funcValidator 自定义验证函数
 // Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
 // Rule.Func        F       必须    使用 Rule.Func 来验证本条 Rule
-func (this *validator) funcValidator(attr string, rule Rule, obj M) E {
-	f := rule.Func
-	if f == nil {
-		panic(errors.New(fmt.Sprint(rule) + " attribute 'Func' not found or empty"))
-	}
+func (this *validator) funcValidator(attr string, rule Rule, obj M) Errors {
+	paramsOf(rule, parseFunc)
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
-	return f(attr, rule, obj)
+	return fromE(rule.Func(attr, rule, obj), attr, rule)
 }
 
 // requiredValidator 必填
-func (this *validator) requiredValidator(attr string, rule Rule, obj M) E {
-	if _, ok := obj[attr]; ok {
+func (this *validator) requiredValidator(attr string, rule Rule, obj M) Errors {
+	if !this.absent(attr, rule, obj) {
+		return nil
+	}
+	return this.failure("required", attr, rule)
+}
+
+// requiredIfValidator 条件必填，Rule.Other 的值在 Rule.Enum 中时必填
+// Rule.Other    string      必选    其他属性
+// Rule.Enum     []string    必选    其他属性的取值范围
+func (this *validator) requiredIfValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseRequiredIf)
+	if !this.absent(attr, rule, obj) {
+		return nil
+	}
+	if value, ok := lookup(obj, p.others[0]); ok {
+		if in, _ := inEnum(value, rule.Enum); in {
+			return this.compared("requiredIf", attr, rule, p.others[:1], "enum", "["+strings.Join(rule.Enum, "、")+"]")
+		}
+	}
+	return nil
+}
+
+// requiredUnlessValidator 条件必填，Rule.Other 的值不在 Rule.Enum 中（或无值）时必填
+// Rule.Other    string      必选    其他属性
+// Rule.Enum     []string    必选    其他属性的取值范围
+func (this *validator) requiredUnlessValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseRequiredIf)
+	if !this.absent(attr, rule, obj) {
 		return nil
 	}
-	return this.generator("required", attr, rule)
+	if value, ok := lookup(obj, p.others[0]); ok {
+		if in, _ := inEnum(value, rule.Enum); in {
+			return nil
+		}
+	}
+	return this.compared("requiredUnless", attr, rule, p.others[:1], "enum", "["+strings.Join(rule.Enum, "、")+"]")
+}
+
+// requiredWithValidator 条件必填，Rule.Other 中任意一个属性有值时必填
+// Rule.Other    string|[]string    必选    其他属性
+func (this *validator) requiredWithValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseRequiredWith)
+	if !this.absent(attr, rule, obj) {
+		return nil
+	}
+	for _, other := range p.others {
+		if value, ok := lookup(obj, other); !this.empty(value, ok, rule) {
+			return this.compared("requiredWith", attr, rule, p.others)
+		}
+	}
+	return nil
+}
+
+// requiredWithoutValidator 条件必填，Rule.Other 中任意一个属性无值时必填
+// Rule.Other    string|[]string    必选    其他属性
+func (this *validator) requiredWithoutValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseRequiredWith)
+	if !this.absent(attr, rule, obj) {
+		return nil
+	}
+	for _, other := range p.others {
+		if value, ok := lookup(obj, other); this.empty(value, ok, rule) {
+			return this.compared("requiredWithout", attr, rule, p.others)
+		}
+	}
+	return nil
 }
 
 // inValidator 枚举
 // 支持类型 int64、int32、int16、int8、int、float64、float32、string、bool
 // Rule.Required    bool        可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
 // Rule.Enum        []string    必须    被验证字段必须在 Rule.Enum 中
-func (this *validator) inValidator(attr string, rule Rule, obj M) E {
+func (this *validator) inValidator(attr string, rule Rule, obj M) Errors {
+	paramsOf(rule, parseIn)
 	enum := rule.Enum
-	if len(enum) == 0 {
-		panic(errors.New(fmt.Sprint(rule) + " attribute 'Enum' not found or empty"))
-	}
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
 	// 枚举检测
+	in, ok := inEnum(obj[attr], enum)
+	if !ok {
+		return this.failure("inValid", attr, rule)
+	}
+	if !in {
+		return this.failure("in", attr, rule, "enum", "["+strings.Join(enum, "、")+"]")
+	}
+	return nil
+}
+
+// inEnum 值是否在枚举中，支持类型 int64、int32、int16、int8、int、float64、float32、string、bool，其他类型 ok 为 false
+func inEnum(value interface{}, enum []string) (in bool, ok bool) {
 	var field string
-	switch v := obj[attr].(type) {
+	switch v := value.(type) {
 	case int64:
 		field = strconv.Itoa(int(v))
 	case int32:
@@ -285,64 +698,50 @@ func (this *validator) inValidator(attr string, rule Rule, obj M) E {
 	case bool:
 		field = strconv.FormatBool(v)
 	default:
-		return this.generator("inValid", attr, rule)
+		return false, false
 	}
-	in := false
 	for _, v := range enum {
 		if v == field {
-			in = true
-			break
+			return true, true
 		}
 	}
-	if !in {
-		return this.generator("in", attr, rule, "["+strings.Join(enum, "、")+"]")
-	}
-	return nil
+	return false, true
 }
 
 // stringValidator 字符串
 // Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
 // Rule.Max         int     可选    被验证字段长度不能大于 Rule.Max
 // Rule.Min         in      可选    被验证字段长度不能小于 Rule.Min
-func (this *validator) stringValidator(attr string, rule Rule, obj M) E {
+func (this *validator) stringValidator(attr string, rule Rule, obj M) Errors {
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
 	// 类型检测
 	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
-		return this.generator("string", attr, rule)
+		return this.failure("string", attr, rule)
 	}
 	// 长度检测
-	max := rule.Max
-	min := rule.Min
+	p := paramsOf(rule, parseString)
+	max := p.max
+	min := p.min
 	if max != nil || min != nil {
-		// 逻辑错误
-		if max != nil && reflect.ValueOf(max).Kind() != reflect.Int {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should be int"))
-		}
-		if min != nil && reflect.ValueOf(min).Kind() != reflect.Int {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Min' should be int"))
-		}
-		if max != nil && min != nil && min.(int) > max.(int) {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should greater than 'Min'"))
-		}
 		// 比较
-		length := int(utf8.RuneCountInString(obj[attr].(string)))
-		if max != nil && min == nil && length > max.(int) { // only Max
-			return this.generator("stringLengthMax", attr, rule, max)
+		length := float64(utf8.RuneCountInString(obj[attr].(string)))
+		if max != nil && min == nil && length > p.fmax { // only Max
+			return this.failure("stringLengthMax", attr, rule, "max", max)
 		}
-		if min != nil && max == nil && length < min.(int) { // only Min
-			return this.generator("stringLengthMin", attr, rule, min)
+		if min != nil && max == nil && length < p.fmin { // only Min
+			return this.failure("stringLengthMin", attr, rule, "min", min)
 		}
-		if max != nil && min != nil && (length > max.(int) || length < min.(int)) { // both
+		if max != nil && min != nil && (length > p.fmax || length < p.fmin) { // both
 			if max != min {
-				return this.generator("stringLengthRange", attr, rule, min, max) // range
+				return this.failure("stringLengthRange", attr, rule, "min", min, "max", max) // range
 			}
-			return this.generator("stringLengthEqual", attr, rule, max) // euqal
+			return this.failure("stringLengthEqual", attr, rule, "max", max) // euqal
 		}
 	}
 	return nil
@@ -354,13 +753,13 @@ func (this *validator) stringValidator(attr string, rule Rule, obj M) E {
 // Rule.Symbol      int64    可选    0(默认) - 正/负数，>0 - 正数(不包含0)，<0 - 负数(不包含0)
 // Rule.Max         int      可选    被验证字段大小不能大于 Rule.Max
 // Rule.Min         int      可选    被验证字段大小不能小于 Rule.Min
-func (this *validator) integerValidator(attr string, rule Rule, obj M) E {
+func (this *validator) integerValidator(attr string, rule Rule, obj M) Errors {
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
 	// 类型检测
 	var field float64
@@ -377,73 +776,58 @@ func (this *validator) integerValidator(attr string, rule Rule, obj M) E {
 		field = float64(v)
 	case float64:
 		if v-float64(int(v)) != 0 { // 带小数位
-			return this.generator("integer", attr, rule)
+			return this.failure("integer", attr, rule)
 		}
 		field = v
 	case float32:
 		if v-float32(int(v)) != 0 { // 带小数位
-			return this.generator("integer", attr, rule)
+			return this.failure("integer", attr, rule)
 		}
 		field = float64(v)
 	case string:
 		f, err := strconv.ParseFloat(v, 64)
 		if err != nil { // 不能转换为 float64
-			return this.generator("integer", attr, rule)
+			return this.failure("integer", attr, rule)
 		}
 		if f-float64(int(f)) != 0 { // 带小数位
-			return this.generator("integer", attr, rule)
+			return this.failure("integer", attr, rule)
 		}
 		field = f
 	default:
-		return this.generator("integer", attr, rule)
+		return this.failure("integer", attr, rule)
 	}
 	// 正负检测
 	symbol := rule.Symbol
 	if (symbol > 0 && field <= 0) || (symbol < 0 && field >= 0) {
 		if symbol > 0 {
-			return this.generator("integerPositive", attr, rule)
+			return this.failure("integerPositive", attr, rule)
 		}
-		return this.generator("integerNegative", attr, rule)
+		return this.failure("integerNegative", attr, rule)
 	}
 	// 大小检测
-	max := rule.Max
-	min := rule.Min
+	p := paramsOf(rule, parseInteger)
+	max := p.max
+	min := p.min
 	if max != nil || min != nil {
 		errPrefix := "integer"
-		// 逻辑错误
-		if max != nil && reflect.ValueOf(max).Kind() != reflect.Int {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should be int"))
-		}
-		if min != nil && reflect.ValueOf(min).Kind() != reflect.Int {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Min' should be int"))
-		}
-		if max != nil && min != nil && min.(int) > max.(int) {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should greater than 'Min'"))
-		}
 		if symbol > 0 {
 			errPrefix += "Positive"
-			if max != nil && max.(int) <= 0 { // 要求被检测属性是正数，而最大值被设置成负数，panic
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should greater than 0 when 'Symbal' = " + strconv.FormatInt(symbol, 10)))
-			}
 		}
 		if symbol < 0 {
 			errPrefix += "Negative"
-			if min != nil && min.(int) >= 0 { // 要求被检测属性是负数，而最小值被设置成正数，panic
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Min' should less than 0 when 'Symbal' = " + strconv.FormatInt(symbol, 10)))
-			}
 		}
 		// 比较
-		if max != nil && min == nil && field > (float64)(max.(int)) { // only Max
-			return this.generator(errPrefix+"Max", attr, rule, max)
+		if max != nil && min == nil && field > p.fmax { // only Max
+			return this.failure(errPrefix+"Max", attr, rule, "max", max)
 		}
-		if min != nil && max == nil && field < (float64)(min.(int)) { // only Min
-			return this.generator(errPrefix+"Min", attr, rule, min)
+		if min != nil && max == nil && field < p.fmin { // only Min
+			return this.failure(errPrefix+"Min", attr, rule, "min", min)
 		}
-		if max != nil && min != nil && (field > (float64)(max.(int)) || field < (float64)(min.(int))) { // both
+		if max != nil && min != nil && (field > p.fmax || field < p.fmin) { // both
 			if max != min { // range
-				return this.generator(errPrefix+"Range", attr, rule, min, max)
+				return this.failure(errPrefix+"Range", attr, rule, "min", min, "max", max)
 			}
-			return this.generator("equal", attr, rule, max) // euqal
+			return this.failure("equal", attr, rule, "max", max) // euqal
 		}
 	}
 	return nil
@@ -455,13 +839,13 @@ func (this *validator) integerValidator(attr string, rule Rule, obj M) E {
 // Rule.Symbol      int64          可选    0(默认) - 正/负数，>0 - 正数(不包含0)，<0 - 负数(不包含0)
 // Rule.Max         int|float64    可选    被验证字段大小不能大于 Rule.Max
 // Rule.Min         int|float64    可选    被验证字段大小不能小于 Rule.Min
-func (this *validator) decimalValidator(attr string, rule Rule, obj M) E {
+func (this *validator) decimalValidator(attr string, rule Rule, obj M) Errors {
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
 	// 类型检测
 	var field float64
@@ -473,78 +857,48 @@ func (this *validator) decimalValidator(attr string, rule Rule, obj M) E {
 	case string:
 		f, err := strconv.ParseFloat(v, 64)
 		if err != nil { // 不能转换为 float64
-			return this.generator("decimal", attr, rule)
+			return this.failure("decimal", attr, rule)
 		}
 		field = f
 	default:
-		return this.generator("decimal", attr, rule)
+		return this.failure("decimal", attr, rule)
 	}
 	// 不带小数位
 	if field-float64(int(field)) == 0 {
-		return this.generator("decimal", attr, rule)
+		return this.failure("decimal", attr, rule)
 	}
 	// 正负检测
 	symbol := rule.Symbol
 	if (symbol > 0 && field <= 0) || (symbol < 0 && field >= 0) {
 		if symbol > 0 {
-			return this.generator("decimalPositive", attr, rule)
+			return this.failure("decimalPositive", attr, rule)
 		}
-		return this.generator("decimalNegative", attr, rule)
+		return this.failure("decimalNegative", attr, rule)
 	}
 	// 大小检测
-	max := rule.Max
-	min := rule.Min
-	var fmax, fmin float64
+	p := paramsOf(rule, parseNumber)
+	max := p.max
+	min := p.min
 	if max != nil || min != nil {
 		errPrefix := "decimal"
-		// 逻辑错误
-		if max != nil {
-			switch v := max.(type) {
-			case float64:
-				fmax = v
-			case int:
-				fmax = float64(v)
-			default:
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should be int or float64"))
-			}
-		}
-		if min != nil {
-			switch v := min.(type) {
-			case float64:
-				fmin = v
-			case int:
-				fmin = float64(v)
-			default:
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Min' should be int or float64"))
-			}
-		}
-		if max != nil && min != nil && fmin > fmax {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should greater than 'Min'"))
-		}
 		if symbol > 0 {
 			errPrefix += "Positive"
-			if max != nil && fmax <= 0 { // 要求被检测属性是正数，而最大值被设置成负数，panic
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should greater than 0 when 'Symbal' = " + strconv.FormatInt(symbol, 10)))
-			}
 		}
 		if symbol < 0 {
 			errPrefix += "Negative"
-			if min != nil && fmin >= 0 { // 要求被检测属性是负数，而最小值被设置成正数，panic
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Min' should less than 0 when 'Symbal' = " + strconv.FormatInt(symbol, 10)))
-			}
 		}
 		// 比较
-		if max != nil && min == nil && field > fmax { // only Max
-			return this.generator(errPrefix+"Max", attr, rule, max)
+		if max != nil && min == nil && field > p.fmax { // only Max
+			return this.failure(errPrefix+"Max", attr, rule, "max", max)
 		}
-		if min != nil && max == nil && field < fmin { // only Min
-			return this.generator(errPrefix+"Min", attr, rule, min)
+		if min != nil && max == nil && field < p.fmin { // only Min
+			return this.failure(errPrefix+"Min", attr, rule, "min", min)
 		}
-		if max != nil && min != nil && (field > fmax || field < fmin) { // both
+		if max != nil && min != nil && (field > p.fmax || field < p.fmin) { // both
 			if max != min { // range
-				return this.generator(errPrefix+"Range", attr, rule, min, max)
+				return this.failure(errPrefix+"Range", attr, rule, "min", min, "max", max)
 			}
-			return this.generator("equal", attr, rule, max) // euqal
+			return this.failure("equal", attr, rule, "max", max) // euqal
 		}
 	}
 	return nil
@@ -556,13 +910,13 @@ func (this *validator) decimalValidator(attr string, rule Rule, obj M) E {
 // Rule.Symbol      int64          可选    0(默认) - 正/负数，>0 - 正数(不包含0)，<0 - 负数(不包含0)
 // Rule.Max         int|float64    可选    被验证字段大小不能大于 Rule.Max
 // Rule.Min         int|float64    可选    被验证字段大小不能小于 Rule.Min
-func (this *validator) numberValidator(attr string, rule Rule, obj M) E {
+func (this *validator) numberValidator(attr string, rule Rule, obj M) Errors {
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
 	// 类型检测
 	var field float64
@@ -584,74 +938,44 @@ func (this *validator) numberValidator(attr string, rule Rule, obj M) E {
 	case string:
 		f, err := strconv.ParseFloat(v, 64)
 		if err != nil { // 不能转换为 float64
-			return this.generator("number", attr, rule)
+			return this.failure("number", attr, rule)
 		}
 		field = f
 	default:
-		return this.generator("number", attr, rule)
+		return this.failure("number", attr, rule)
 	}
 	// 正负检测
 	symbol := rule.Symbol
 	if (symbol > 0 && field <= 0) || (symbol < 0 && field >= 0) {
 		if symbol > 0 {
-			return this.generator("numberPositive", attr, rule)
+			return this.failure("numberPositive", attr, rule)
 		}
-		return this.generator("numberNegative", attr, rule)
+		return this.failure("numberNegative", attr, rule)
 	}
 	// 大小检测
-	max := rule.Max
-	min := rule.Min
-	var fmax, fmin float64
+	p := paramsOf(rule, parseNumber)
+	max := p.max
+	min := p.min
 	if max != nil || min != nil {
 		errPrefix := "number"
-		// 逻辑错误
-		if max != nil {
-			switch v := max.(type) {
-			case float64:
-				fmax = v
-			case int:
-				fmax = float64(v)
-			default:
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should be int or float64"))
-			}
-		}
-		if min != nil {
-			switch v := min.(type) {
-			case float64:
-				fmin = v
-			case int:
-				fmin = float64(v)
-			default:
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Min' should be int or float64"))
-			}
-		}
-		if max != nil && min != nil && fmin > fmax {
-			panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should greater than 'Min'"))
-		}
 		if symbol > 0 {
 			errPrefix += "Positive"
-			if max != nil && fmax <= 0 { // 要求被检测属性是正数，而最大值被设置成负数，panic
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Max' should greater than 0 when 'Symbal' = " + strconv.FormatInt(symbol, 10)))
-			}
 		}
 		if symbol < 0 {
 			errPrefix += "Negative"
-			if min != nil && fmin >= 0 { // 要求被检测属性是负数，而最小值被设置成正数，panic
-				panic(errors.New(fmt.Sprint(rule) + " attribute 'Min' should less than 0 when 'Symbal' = " + strconv.FormatInt(symbol, 10)))
-			}
 		}
 		// 比较
-		if max != nil && min == nil && field > fmax { // only Max
-			return this.generator(errPrefix+"Max", attr, rule, max)
+		if max != nil && min == nil && field > p.fmax { // only Max
+			return this.failure(errPrefix+"Max", attr, rule, "max", max)
 		}
-		if min != nil && max == nil && field < fmin { // only Min
-			return this.generator(errPrefix+"Min", attr, rule, min)
+		if min != nil && max == nil && field < p.fmin { // only Min
+			return this.failure(errPrefix+"Min", attr, rule, "min", min)
 		}
-		if max != nil && min != nil && (field > fmax || field < fmin) { // both
+		if max != nil && min != nil && (field > p.fmax || field < p.fmin) { // both
 			if max != min { // range
-				return this.generator(errPrefix+"Range", attr, rule, min, max)
+				return this.failure(errPrefix+"Range", attr, rule, "min", min, "max", max)
 			}
-			return this.generator("equal", attr, rule, max) // euqal
+			return this.failure("equal", attr, rule, "max", max) // euqal
 		}
 	}
 	return nil
@@ -660,13 +984,13 @@ func (this *validator) numberValidator(attr string, rule Rule, obj M) E {
 // boolValidator 布尔（布尔值/字符串表示的布尔值[1、0、t、f、true、false(忽略大小写)]）
 // 支持类型 bool、string
 // Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
-func (this *validator) booleanValidator(attr string, rule Rule, obj M) E {
+func (this *validator) booleanValidator(attr string, rule Rule, obj M) Errors {
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
 	// 类型检测
 	switch v := obj[attr].(type) {
@@ -674,85 +998,107 @@ func (this *validator) booleanValidator(attr string, rule Rule, obj M) E {
 		return nil
 	case string:
 		if _, err := strconv.ParseBool(strings.ToLower(v)); err != nil {
-			return this.generator("boolean", attr, rule)
+			return this.failure("boolean", attr, rule)
 		}
 	default:
-		return this.generator("boolean", attr, rule)
+		return this.failure("boolean", attr, rule)
 	}
 	return nil
 }
 
 // ipValidator ipv4/ipv6
-func (this *validator) ipValidator(attr string, rule Rule, obj M) E {
-	// 必填检测
-	if _, ok := obj[attr]; !ok {
-		if !rule.Required { // 允许为空
-			return nil
-		}
-		return this.generator("required", attr, rule)
-	}
-	// 字符串检测
-	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
-		return this.generator("string", attr, rule)
-	}
-	// ip 检测
-	if ip := net.ParseIP(obj[attr].(string)); ip == nil {
-		return this.generator("ip", attr, rule)
-	}
-	return nil
+// Rule.Required    bool        可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Networks    []string    可选    允许的网段（CIDR），如 10.0.0.0/8、2001:db8::/32，不属于任一网段报 ipNetwork 错误
+// Rule.Classes     []string    可选    允许的地址分类，IP_PUBLIC、IP_PRIVATE、IP_LOOPBACK、IP_MULTICAST、IP_LINK_LOCAL、IP_UNSPECIFIED，不属于任一分类报 ipClass 错误
+func (this *validator) ipValidator(attr string, rule Rule, obj M) Errors {
+	return this.ipAddress("ip", attr, rule, obj)
 }
 
 // regexValidator 正则
 // Rule.Required    bool      可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
 // Rule.Pattern     string    必选    正则模式字符串
-func (this *validator) regexValidator(attr string, rule Rule, obj M) E {
-	pattern := rule.Pattern
-	if pattern == "" {
-		panic(errors.New(fmt.Sprint(rule) + " attribute 'Pattern' not found or empty"))
-	}
+func (this *validator) regexValidator(attr string, rule Rule, obj M) Errors {
+	p := paramsOf(rule, parseRegex)
 	// 必填检测
-	if _, ok := obj[attr]; !ok {
+	if this.absent(attr, rule, obj) {
 		if !rule.Required { // 允许为空
 			return nil
 		}
-		return this.generator("required", attr, rule)
+		return this.failure("required", attr, rule)
 	}
 	// 字符串检测
 	if reflect.ValueOf(obj[attr]).Kind() != reflect.String {
-		return this.generator("string", attr, rule)
+		return this.failure("string", attr, rule)
 	}
 	// 正则检测
-	regex := regexp.MustCompile(pattern)
-	if !regex.MatchString(obj[attr].(string)) {
-		return this.generator(rule.Rule, attr, rule)
+	if !p.regex.MatchString(obj[attr].(string)) {
+		return this.failure(rule.Rule, attr, rule)
 	}
 	return nil
 }
 
 // emailValidator 邮箱
 // Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
-func (this *validator) emailValidator(attr string, rule Rule, obj M) E {
+func (this *validator) emailValidator(attr string, rule Rule, obj M) Errors {
 	rule.Pattern = PATTERN_EMAIL
 	return this.regexValidator(attr, rule, obj)
 }
 
 // mobileValidator 中国大陆座机号
 // Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
-func (this *validator) telValidator(attr string, rule Rule, obj M) E {
+func (this *validator) telValidator(attr string, rule Rule, obj M) Errors {
 	rule.Pattern = PATTERN_TEL
 	return this.regexValidator(attr, rule, obj)
 }
 
 // mobileValidator 中国大陆手机号
 // Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
-func (this *validator) mobileValidator(attr string, rule Rule, obj M) E {
+func (this *validator) mobileValidator(attr string, rule Rule, obj M) Errors {
 	rule.Pattern = PATTERN_MOBILE
 	return this.regexValidator(attr, rule, obj)
 }
 
 // mobileValidator 中国大陆邮编
 // Rule.Required    bool    可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
-func (this *validator) zipcodeValidator(attr string, rule Rule, obj M) E {
+func (this *validator) zipcodeValidator(attr string, rule Rule, obj M) Errors {
 	rule.Pattern = PATTERN_ZIPCODE
 	return this.regexValidator(attr, rule, obj)
 }
+
+// objectValidator 嵌套对象，被验证字段支持类型 map[string]interface{}、M、结构体及其指针，对象的属性使用 Rule.Rules 验证
+// Rule.Required    bool           可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Rules       ScenceRules    必选    对象的验证规则集
+func (this *validator) objectValidator(attr string, rule Rule, obj M) Errors {
+	paramsOf(rule, parseRules)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 类型检测
+	if _, ok := object(obj[attr]); !ok {
+		return this.failure("object", attr, rule)
+	}
+	return nil
+}
+
+// eachValidator 数组，被验证字段支持任意类型的切片、数组，数组的每个元素（必须是对象）使用 Rule.Rules 验证
+// Rule.Required    bool           可选    false(默认) - 被验证字段有值验证/无值跳过，true - 被验证字段无值，验证失败，报 reqired 错误
+// Rule.Rules       ScenceRules    必选    数组元素的验证规则集
+func (this *validator) eachValidator(attr string, rule Rule, obj M) Errors {
+	paramsOf(rule, parseRules)
+	// 必填检测
+	if this.absent(attr, rule, obj) {
+		if !rule.Required { // 允许为空
+			return nil
+		}
+		return this.failure("required", attr, rule)
+	}
+	// 类型检测
+	if _, ok := array(obj[attr]); !ok {
+		return this.failure("each", attr, rule)
+	}
+	return nil
+}
@@ -1,9 +1,11 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	// "github.com/goindow/toolbox"
 )
@@ -24,9 +26,15 @@ func init() {
 	// toolbox.Dump(v)
 }
 
+// generator 生成错误信息，{label}、{attr} 替换为 new，其他占位符按出现顺序替换为 placeholder
 func generator(message, new string, placeholder ...interface{}) string {
-	e := strings.Replace(message, "{label}", new, -1)
-	return fmt.Sprintf(e, placeholder...)
+	params := map[string]interface{}{PLACEHOLDER_LABEL: new, PLACEHOLDER_ATTR: new}
+	for _, name := range placeholders(message) {
+		if _, ok := params[name]; !ok && len(placeholder) != 0 {
+			params[name], placeholder = placeholder[0], placeholder[1:]
+		}
+	}
+	return format(message, params)
 }
 
 func fail(t *testing.T, s string) {
@@ -92,6 +100,166 @@ func Test_Valiadte_Undefined_Scence(t *testing.T) {
 	v.Validate(rulesEmpty, objEmpty, SCENCE)
 }
 
+// 并发验证，共享同一个实例，错误信息互不干扰（go test -race）
+func Test_Validate_Concurrent(t *testing.T) {
+	shared := New()
+	shared.AddValidator("even", func(attr string, rule Rule, obj M) E {
+		if n, ok := obj[attr].(int); ok && n%2 != 0 {
+			return E{attr: "必须是偶数"}
+		}
+		return nil
+	})
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "required"},
+			{Attr: "age", Rule: "int", Min: 18},
+			{Attr: "count", Rule: "even"},
+		},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var obj M
+				want := 0
+				if (i+j)%2 == 0 { // 全部通过
+					obj = M{"username": "hyb", "age": 18 + j, "count": 2 * j}
+				} else { // 全部失败
+					obj = M{"age": j % 18, "count": 2*j + 1}
+					want = 3
+				}
+				e := shared.Validate(rules, obj, "create")
+				if len(e) != want {
+					fail(t, "goroutine "+strconv.Itoa(i)+" should print "+strconv.Itoa(want)+" errors, got "+fmt.Sprint(e))
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// 并发验证的同时切换语言、添加验证器（go test -race）
+func Test_Validate_Concurrent_Configure(t *testing.T) {
+	shared := New()
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "required"},
+		},
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if e := shared.Validate(rules, objEmpty, "create"); len(e) != 1 {
+					fail(t, "should print 1 error, got "+fmt.Sprint(e))
+					return
+				}
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				shared.Lang(EN_US)
+			} else {
+				shared.Lang(ZH_CN)
+			}
+			shared.AddValidator("custom"+strconv.Itoa(i), func(attr string, rule Rule, obj M) E {
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+}
+
+/***** ValidateE() *****/
+
+// 规则定义错误以 error 返回
+func Test_ValidateE_RuleError(t *testing.T) {
+	cases := []struct {
+		name   string
+		rules  Rules
+		scence Scence
+		err    error
+	}{
+		{"undefined scence", rulesEmpty, "undefinde_scence", ErrSceneUndefined},
+		{"undefined rule", Rules{"create": {{Attr: "username", Rule: "undefined_rule"}}}, "create", ErrValidatorUndefined},
+		{"notfound rule", Rules{"create": {{Attr: "username"}}}, "create", ErrInvalidRule},
+		{"notfound attr", Rules{"create": {{Rule: "required"}}}, "create", ErrInvalidRule},
+		{"typeerr attr", Rules{"create": {{Attr: 1234, Rule: "required"}}}, "create", ErrInvalidRule},
+		{"notfound enum", Rules{"create": {{Attr: "gender", Rule: "in"}}}, "create", ErrInvalidRuleParam},
+		{"notfound pattern", Rules{"create": {{Attr: "password", Rule: "regex"}}}, "create", ErrInvalidRuleParam},
+		{"notfound func", Rules{"create": {{Attr: "password", Rule: "func"}}}, "create", ErrInvalidRuleParam},
+		{"typeerr max", Rules{"create": {{Attr: "age", Rule: "int", Max: "18"}}}, "create", ErrInvalidRuleParam},
+		{"logicerr max min", Rules{"create": {{Attr: "weight", Rule: "number", Max: 1, Min: 2.5}}}, "create", ErrInvalidRuleParam},
+	}
+	obj := M{"username": "hyb", "gender": "male", "password": "******", "age": 18, "weight": 2}
+	for _, c := range cases {
+		e, err := v.ValidateE(c.rules, obj, c.scence)
+		// toolbox.Dump(err) // undefinde_scence scence undefined
+		if !errors.Is(err, c.err) {
+			fail(t, c.name+": should return error("+c.err.Error()+"), got "+fmt.Sprint(err))
+		}
+		var re *RuleError
+		if !errors.As(err, &re) || re.Error() == "" {
+			fail(t, c.name+": should return *RuleError")
+		}
+		if e != nil {
+			fail(t, c.name+": should return nil []E")
+		}
+	}
+}
+
+// 规则定义正确，返回验证错误
+func Test_ValidateE(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "required"},
+		},
+	}
+	message := generator(v.default_errors["required"], "username")
+	e, err := v.ValidateE(rules, objEmpty, "create")
+	// toolbox.Dump(e) // [map[username:不能为空]]
+	if err != nil {
+		fail(t, "should return nil error, got "+err.Error())
+	}
+	if len(e) == 0 || e[0]["username"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 自定义验证函数中的 panic 不会被转换为 error
+func Test_ValidateE_Func_Panic(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "username", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				panic("boom")
+			}},
+		},
+	}
+	defer func() {
+		if p := recover(); p != "boom" {
+			fail(t, "should panic(boom)")
+		}
+	}()
+	v.ValidateE(rules, M{"username": "hyb"}, "create")
+}
+
+// Panic API 的 panic 值同样是 *RuleError
+func Test_Validate_Panic_RuleError(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrSceneUndefined) {
+			fail(t, "should panic(*RuleError) wrapping ErrSceneUndefined")
+		}
+	}()
+	v.Validate(rulesEmpty, objEmpty, "undefinde_scence")
+}
+
 /***** dispatch() *****/
 
 // 未定义验证器 Rule.Rule
@@ -381,6 +549,347 @@ func Test_Rule_RequiredValidator_OK(t *testing.T) {
 	}
 }
 
+/***** requiredIfValidator *****/
+
+// 其他属性的值在 Rule.Enum 中，无值
+func Test_Rule_RequiredIfValidator(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "invoice_title", Rule: "required_if", Other: "need_invoice", Enum: []string{"true"}},
+		},
+	}
+	obj := map[string]interface{}{"need_invoice": true}
+	message := generator(v.default_errors["requiredIf"], "invoice_title", "need_invoice", "[true]")
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[invoice_title:need_invoice 为 [true] 中的一个时不能为空]]
+	if len(e) == 0 || e[0]["invoice_title"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 其他属性的值不在 Rule.Enum 中或无值
+func Test_Rule_RequiredIfValidator_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "invoice_title", Rule: "required_if", Other: "need_invoice", Enum: []string{"true"}},
+			{Attr: "invoice.title", Rule: "required_if", Other: "invoice.type", Enum: []string{"1", "2"}},
+		},
+	}
+	objs := []M{
+		objEmpty,
+		{"need_invoice": false, "invoice": map[string]interface{}{"type": float64(0)}},
+		{"need_invoice": true, "invoice_title": "goindow", "invoice": map[string]interface{}{"type": float64(2), "title": "goindow"}},
+	}
+	for _, obj := range objs {
+		if e := v.Validate(rules, obj, "create"); len(e) != 0 {
+			fail(t, "should print nothing, got "+fmt.Sprint(e))
+		}
+	}
+}
+
+// 未传参 Rule.Other、Rule.Enum
+func Test_Rule_RequiredIfValidator_NotFound_Other(t *testing.T) {
+	for _, rule := range []Rule{
+		{Attr: "invoice_title", Rule: "required_if", Enum: []string{"true"}},
+		{Attr: "invoice_title", Rule: "required_if", Other: []string{"need_invoice"}, Enum: []string{"true"}},
+		{Attr: "invoice_title", Rule: "required_if", Other: "need_invoice"},
+	} {
+		if _, err := v.ValidateE(Rules{"create": {rule}}, objEmpty, "create"); !errors.Is(err, ErrInvalidRuleParam) {
+			fail(t, "should return error(invalid rule param), got "+fmt.Sprint(err))
+		}
+	}
+}
+
+/***** requiredUnlessValidator *****/
+
+// 其他属性的值不在 Rule.Enum 中或无值，无值
+func Test_Rule_RequiredUnlessValidator(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "email", Rule: "required_unless", Other: "notify", Enum: []string{"none", "sms"}},
+		},
+	}
+	message := generator(v.default_errors["requiredUnless"], "email", "notify", "[none、sms]")
+	for _, obj := range []M{objEmpty, {"notify": "email"}} {
+		e := v.Validate(rules, obj, "create")
+		// toolbox.Dump(e) // [map[email:notify 不为 [none、sms] 中的一个时不能为空]]
+		if len(e) == 0 || e[0]["email"] != message {
+			fail(t, "should print error("+message+")")
+		}
+	}
+}
+
+// 其他属性的值在 Rule.Enum 中
+func Test_Rule_RequiredUnlessValidator_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "email", Rule: "required_unless", Other: "notify", Enum: []string{"none", "sms"}},
+		},
+	}
+	obj := map[string]interface{}{"notify": "sms"}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing")
+	}
+}
+
+/***** requiredWithValidator *****/
+
+// 任意一个其他属性有值，无值
+func Test_Rule_RequiredWithValidator(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "city", Rule: "required_with", Other: []string{"province", "zipcode"}},
+		},
+	}
+	obj := map[string]interface{}{"zipcode": "333000"}
+	message := generator(v.default_errors["requiredWith"], "city", "province、zipcode")
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[city:province、zipcode 存在时不能为空]]
+	if len(e) == 0 || e[0]["city"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 其他属性均无值
+func Test_Rule_RequiredWithValidator_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "city", Rule: "required_with", Other: "province"},
+		},
+	}
+	e := v.Validate(rules, objEmpty, "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing")
+	}
+}
+
+/***** requiredWithoutValidator *****/
+
+// 任意一个其他属性无值，无值
+func Test_Rule_RequiredWithoutValidator(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "email", Rule: "required_without", Other: []string{"mobile", "tel"}},
+		},
+	}
+	obj := map[string]interface{}{"mobile": "15990573367"}
+	message := generator(v.default_errors["requiredWithout"], "email", "mobile、tel")
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[email:mobile、tel 不存在时不能为空]]
+	if len(e) == 0 || e[0]["email"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 其他属性均有值
+func Test_Rule_RequiredWithoutValidator_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "email", Rule: "required_without", Other: []string{"mobile", "tel"}},
+		},
+	}
+	obj := map[string]interface{}{"mobile": "15990573367", "tel": "0791-1234567"}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing")
+	}
+}
+
+/***** Rule.When *****/
+
+// 条件不满足，跳过
+func Test_Rule_When(t *testing.T) {
+	needInvoice := func(obj M) bool {
+		return obj["need_invoice"] == true
+	}
+	rules := Rules{
+		"create": {
+			{Attr: "invoice_title", Rule: "string", Required: true, Max: 5, When: needInvoice},
+		},
+	}
+	if e := v.Validate(rules, M{"need_invoice": false, "invoice_title": 1}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	message := generator(v.default_errors["required"], "invoice_title")
+	e := v.Validate(rules, M{"need_invoice": true}, "create")
+	// toolbox.Dump(e) // [map[invoice_title:不能为空]]
+	if len(e) == 0 || e[0]["invoice_title"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 嵌套验证中，参数为被验证属性所在的对象
+func Test_Rule_When_Nested(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items", Rule: "each", Rules: ScenceRules{
+				{Attr: "sku", Rule: "required", When: func(obj M) bool { return obj["type"] == "goods" }},
+			}},
+		},
+	}
+	obj := M{"items": []interface{}{M{"type": "goods"}, M{"type": "service"}}}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[items.0.sku:不能为空]]
+	if len(e) != 1 || e[0]["items.0.sku"] != v.default_errors["required"] {
+		fail(t, "should print error of items.0.sku, got "+fmt.Sprint(e))
+	}
+}
+
+// 嵌套属性路径，参数为每个具体路径所在的对象
+func Test_Rule_When_Path(t *testing.T) {
+	var got []M
+	rules := Rules{
+		"create": {
+			{Attr: "address.city", Rule: "required", When: func(obj M) bool {
+				got = append(got, obj)
+				return obj["country"] == "cn"
+			}},
+			{Attr: "items.*.sku", Rule: "required", When: func(obj M) bool { return obj["type"] == "goods" }},
+			{Attr: "items.*.name", Rule: "trim", When: func(obj M) bool { return obj["type"] == "goods" }},
+		},
+	}
+	address := M{"country": "cn"}
+	obj := M{"address": address, "items": []interface{}{M{"type": "goods", "name": " a "}, M{"type": "service", "name": " b "}}}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[address.city:不能为空] map[items.0.sku:不能为空]]
+	want := []E{{"address.city": v.default_errors["required"]}, {"items.0.sku": v.default_errors["required"]}}
+	if fmt.Sprint(e) != fmt.Sprint(want) {
+		fail(t, "should print "+fmt.Sprint(want)+", got "+fmt.Sprint(e))
+	}
+	if len(got) != 1 || fmt.Sprint(got[0]) != fmt.Sprint(address) {
+		fail(t, "should receive address, got "+fmt.Sprint(got))
+	}
+	if e := v.Validate(rules, M{"address": M{"country": "us"}}, "create"); len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+	// 过滤器
+	clean, _ := v.ValidateAndClean(rules, obj, "create")
+	if want := "[map[name:a type:goods] map[name: b  type:service]]"; fmt.Sprint(clean["items"]) != want {
+		fail(t, "should print "+want+", got "+fmt.Sprint(clean["items"]))
+	}
+}
+
+/***** Rule.Bail *****/
+
+// 验证失败后，该属性的后续规则不再验证，其他属性不受影响
+func Test_Rule_Bail(t *testing.T) {
+	called := 0
+	rules := Rules{
+		"create": {
+			{Attr: []string{"username", "password"}, Rule: "required", Bail: true},
+			{Attr: "username", Rule: "string", Required: true, Min: 3},
+			{Attr: "password", Rule: "regex", Pattern: `[A-Z]{1}\w{5,}`, Bail: true},
+			{Attr: "password", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				called++
+				return nil
+			}},
+			{Attr: "age", Rule: "int", Min: 18},
+		},
+	}
+	obj := M{"password": "******", "age": 17}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[username:不能为空] map[password:格式不正确] map[age:必须是不小于 18 的整数]]
+	if len(e) != 3 || e[0]["username"] != v.default_errors["required"] || e[1]["password"] == "" || e[2]["age"] == "" {
+		fail(t, "should print 3 errors(username、password、age), got "+fmt.Sprint(e))
+	}
+	if called != 0 {
+		fail(t, "func rule of password should not be called")
+	}
+	// 未设置 Rule.Bail，后续规则继续验证
+	rules["create"][0].Bail = false
+	if e := v.Validate(rules, obj, "create"); len(e) != 4 {
+		fail(t, "should print 4 errors, got "+fmt.Sprint(e))
+	}
+}
+
+// 嵌套属性路径按具体路径中止验证，嵌套验证失败同样中止
+func Test_Rule_Bail_Nested(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items.*.sku", Rule: "required", Bail: true},
+			{Attr: "items.*.sku", Rule: "string", Min: 3},
+			{Attr: "address", Rule: "object", Bail: true, Rules: ScenceRules{
+				{Attr: "city", Rule: "required"},
+			}},
+			{Attr: "address", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				return E{attr: "should not be called"}
+			}},
+		},
+	}
+	obj := M{
+		"items":   []interface{}{M{"sku": "a"}, M{}},
+		"address": M{},
+	}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[items.1.sku:不能为空] map[items.0.sku:长度不能小于 3] map[address.city:不能为空]]
+	if len(e) != 3 || e[0]["items.1.sku"] == "" || e[1]["items.0.sku"] == "" || e[2]["address.city"] == "" {
+		fail(t, "should print 3 errors(items.1.sku、items.0.sku、address.city), got "+fmt.Sprint(e))
+	}
+}
+
+/***** FailFast() *****/
+
+// 出现第一个错误后停止验证整个场景
+func Test_Validate_FailFast(t *testing.T) {
+	called := 0
+	rules := Rules{
+		"create": {
+			{Attr: []string{"username", "password"}, Rule: "required"},
+			{Attr: "password", Rule: "regex", Pattern: `[A-Z]{1}\w{5,}`, Message: "密码必须由大写字母开头"},
+			{Attr: "gender", Rule: "in", Enum: []string{"0", "1"}},
+			{Attr: "age", Rule: "func", Func: func(attr string, rule Rule, obj M) E {
+				called++
+				return nil
+			}},
+		},
+	}
+	obj := M{"password": "******", "gender": "male", "age": 17}
+	e := v.Validate(rules, obj, "create", FailFast())
+	// toolbox.Dump(e) // [map[username:不能为空]]
+	if len(e) != 1 || e[0]["username"] != v.default_errors["required"] {
+		fail(t, "should print error(username 不能为空) only, got "+fmt.Sprint(e))
+	}
+	if called != 0 {
+		fail(t, "func rule of age should not be called")
+	}
+	// 验证通过时执行所有规则
+	obj["username"], obj["password"], obj["gender"] = "hyb", "Abc123", "1"
+	if e := v.Validate(rules, obj, "create", FailFast()); len(e) != 0 || called != 1 {
+		fail(t, "should print nothing and call func rule, got "+fmt.Sprint(e))
+	}
+	// 未设置 FailFast，不影响后续调用
+	delete(obj, "username")
+	obj["gender"] = "male"
+	if e := v.Validate(rules, obj, "create"); len(e) != 2 {
+		fail(t, "should print 2 errors, got "+fmt.Sprint(e))
+	}
+}
+
+// 嵌套验证中出现错误，停止验证整个场景
+func Test_Validate_FailFast_Nested(t *testing.T) {
+	schema := v.MustCompile(Rules{
+		"create": {
+			{Attr: "items", Rule: "each", Rules: ScenceRules{
+				{Attr: []string{"sku", "count"}, Rule: "required"},
+			}},
+			{Attr: "username", Rule: "required"},
+		},
+	})
+	obj := M{"items": []interface{}{M{"sku": "a", "count": 1}, M{}, "c"}}
+	e := schema.Validate(obj, "create", FailFast())
+	// toolbox.Dump(e) // [map[items.1.sku:不能为空]]
+	if len(e) != 1 || e[0]["items.1.sku"] == "" {
+		fail(t, "should print error of items.1.sku only, got "+fmt.Sprint(e))
+	}
+	if e := schema.Validate(obj, "create"); len(e) != 4 {
+		fail(t, "should print 4 errors, got "+fmt.Sprint(e))
+	}
+}
+
 /***** inValidator *****/
 
 // 有值，不在 Rule.Enum 内
@@ -3513,3 +4022,208 @@ func Test_Rule_ZipcodeValidator_Required_True_Empty(t *testing.T) {
 		fail(t, "should print error("+message+")")
 	}
 }
+
+/***** objectValidator *****/
+
+var rulesAddress = ScenceRules{
+	{Attr: "city", Rule: "required"},
+	{Attr: "zipcode", Rule: "zipcode"},
+}
+
+// 有值，嵌套验证失败，错误信息的键以父属性为前缀
+func Test_Rule_ObjectValidator(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address", Rule: "object", Rules: rulesAddress},
+		},
+	}
+	obj := map[string]interface{}{"address": map[string]interface{}{"zipcode": "0123"}}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[address.city:不能为空] map[address.zipcode:无效的邮编]]
+	if len(e) != 2 || e[0]["address.city"] != v.default_errors["required"] || e[1]["address.zipcode"] != v.default_errors["zipcode"] {
+		fail(t, "should print errors of address.city and address.zipcode, got "+fmt.Sprint(e))
+	}
+}
+
+// 有值，验证通过
+func Test_Rule_ObjectValidator_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address", Rule: "object", Rules: rulesAddress},
+		},
+	}
+	obj := map[string]interface{}{"address": M{"city": "shanghai", "zipcode": "333000"}}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing")
+	}
+}
+
+// 有值，类型错误，不再嵌套验证
+func Test_Rule_ObjectValidator_TypeErr(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address", Rule: "object", Rules: rulesAddress},
+		},
+	}
+	obj := map[string]interface{}{"address": "shanghai"}
+	message := generator(v.default_errors["object"], "address")
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[address:必须是对象]]
+	if len(e) != 1 || e[0]["address"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 无值 Rule.Required == false
+func Test_Rule_ObjectValidator_Required_False_Empty(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address", Rule: "object", Rules: rulesAddress},
+		},
+	}
+	e := v.Validate(rules, objEmpty, "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing")
+	}
+}
+
+// 无值 Rule.Required == true
+func Test_Rule_ObjectValidator_Required_True_Empty(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address", Rule: "object", Rules: rulesAddress, Required: true},
+		},
+	}
+	message := generator(v.default_errors["required"], "address")
+	e := v.Validate(rules, objEmpty, "create")
+	// toolbox.Dump(e) // [map[address:不能为空]]
+	if len(e) != 1 || e[0]["address"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}
+
+// 未传参 Rule.Rules
+func Test_Rule_ObjectValidator_NotFound_Rules(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address", Rule: "object"},
+		},
+	}
+	defer func() {
+		p := recover()
+		// toolbox.Dump(p) // {address object ...} attribute 'Rules' not found or empty
+		if p == nil {
+			fail(t, "should panic("+fmt.Sprint(rules["create"][0])+" attribute 'Rules' not found or empty)")
+		}
+	}()
+	v.Validate(rules, objEmpty, "create")
+}
+
+// 嵌套规则定义错误
+func Test_Rule_ObjectValidator_Nested_RuleError(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "address", Rule: "object", Rules: ScenceRules{{Attr: "city", Rule: "undefined_rule"}}},
+		},
+	}
+	if _, err := v.Compile(rules); !errors.Is(err, ErrValidatorUndefined) {
+		fail(t, "should return error(undefined_rule validator undefined)")
+	}
+}
+
+/***** eachValidator *****/
+
+var rulesItems = ScenceRules{
+	{Attr: "sku", Rule: "string", Required: true},
+	{Attr: "count", Rule: "int", Symbol: 1},
+	{Attr: "address", Rule: "object", Rules: rulesAddress},
+}
+
+// 有值，数组元素验证失败，错误信息的键以父属性及下标为前缀
+func Test_Rule_EachValidator(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items", Rule: "each", Rules: rulesItems},
+		},
+	}
+	obj := map[string]interface{}{"items": []interface{}{
+		map[string]interface{}{"sku": "A001", "count": float64(1)},
+		map[string]interface{}{"count": float64(0), "address": map[string]interface{}{"zipcode": "333000"}},
+		"A003",
+	}}
+	want := fmt.Sprint([]E{
+		{"items.1.sku": v.default_errors["required"]},
+		{"items.1.count": v.default_errors["integerPositive"]},
+		{"items.1.address.city": v.default_errors["required"]},
+		{"items.2": v.default_errors["object"]},
+	})
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e)
+	if fmt.Sprint(e) != want {
+		fail(t, "should print "+want+", got "+fmt.Sprint(e))
+	}
+}
+
+// 有值，验证通过
+func Test_Rule_EachValidator_OK(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items", Rule: "each", Rules: rulesItems},
+		},
+	}
+	obj := map[string]interface{}{"items": []M{{"sku": "A001"}, {"sku": "A002", "count": 2}}}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // []
+	if len(e) != 0 {
+		fail(t, "should print nothing, got "+fmt.Sprint(e))
+	}
+}
+
+// 有值，类型错误
+func Test_Rule_EachValidator_TypeErr(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items", Rule: "each", Rules: rulesItems},
+		},
+	}
+	obj := map[string]interface{}{"items": map[string]interface{}{"sku": "A001"}}
+	message := generator(v.default_errors["each"], "items")
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[items:必须是数组]]
+	if len(e) != 1 || e[0]["items"] != message {
+		fail(t, "should print error("+message+"), got "+fmt.Sprint(e))
+	}
+}
+
+// 嵌套属性路径与嵌套验证组合使用
+func Test_Rule_EachValidator_Path(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "order.items", Rule: "each", Rules: rulesItems, Required: true},
+		},
+	}
+	obj := map[string]interface{}{"order": map[string]interface{}{"items": []interface{}{map[string]interface{}{}}}}
+	e := v.Validate(rules, obj, "create")
+	// toolbox.Dump(e) // [map[order.items.0.sku:不能为空]]
+	if len(e) != 1 || e[0]["order.items.0.sku"] != v.default_errors["required"] {
+		fail(t, "should print error of order.items.0.sku, got "+fmt.Sprint(e))
+	}
+}
+
+// 无值 Rule.Required == true
+func Test_Rule_EachValidator_Required_True_Empty(t *testing.T) {
+	rules := Rules{
+		"create": {
+			{Attr: "items", Rule: "each", Rules: rulesItems, Required: true},
+		},
+	}
+	message := generator(v.default_errors["required"], "items")
+	e := v.Validate(rules, objEmpty, "create")
+	// toolbox.Dump(e) // [map[items:不能为空]]
+	if len(e) != 1 || e[0]["items"] != message {
+		fail(t, "should print error("+message+")")
+	}
+}